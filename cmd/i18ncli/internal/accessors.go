@@ -0,0 +1,268 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	templateFieldRegexp  = regexp.MustCompile(`{{-?\s*\.([A-Za-z_][A-Za-z0-9_]*)`) // 模板消息中的字段，如 {{.Name}}
+	templateActionRegexp = regexp.MustCompile(`{{.*?}}`)                           // 模板动作
+	printfVerbRegexp     = regexp.MustCompile(`%[-+# 0-9.*\[\]]*[a-zA-Z%]`)        // printf 动词
+)
+
+// accessorParam 访问函数的参数
+type accessorParam struct {
+	Name  string // 参数名
+	Type  string // 参数类型
+	Field string // 模板字段名，printf 参数为空
+}
+
+// GenerateAccessors 根据资源目录下的翻译文件，为每个bundle生成一个Go文件，每条消息对应一个类型安全的访问函数，
+// 函数通过 i18n.Bundle 创建消息，翻译键与直接调用 Str 时完全一致
+func (g *Generator) GenerateAccessors(resDir, outDir string) error {
+	resourceDir := g.resourceDir(resDir)
+	outputDir := g.resourceDir(outDir)
+
+	catalog, err := g.readCatalog(resourceDir)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return err
+	}
+
+	pkg := packageName(outputDir)
+	funcNames := map[string]bool{} // 包级别的名称，包括函数和 bundle 变量
+	fileNames := map[string]bool{}
+
+	for _, bundle := range sortedKeys(catalog) {
+		// 所有语言中出现的键
+		keys := map[string]struct{}{}
+		for _, kv := range catalog[bundle] {
+			for key := range kv {
+				keys[key] = struct{}{}
+			}
+		}
+
+		// 不同的 bundle 名称可能得到相同的标识符，如 user-admin 和 user_admin
+		bundleVar := uniqueName(funcNames, lowerFirst(identifier(bundle, "bundle"))+"Bundle", "")
+
+		var buf bytes.Buffer
+		fmt.Fprintf(&buf, "// Code generated by i18ncli. DO NOT EDIT.\n\n")
+		fmt.Fprintf(&buf, "package %s\n\n", pkg)
+		fmt.Fprintf(&buf, "import (\n\t\"github.com/epkgs/i18n\"\n\t\"github.com/epkgs/i18n/types\"\n)\n\n")
+		fmt.Fprintf(&buf, "var %s = i18n.Bundle(%s)\n", bundleVar, strconv.Quote(bundle))
+
+		for _, key := range sortedKeys(keys) {
+			name := uniqueName(funcNames, identifier(key, "Msg"), identifier(bundle, ""))
+			params := accessorParams(key, bundleVar)
+
+			args := []string{strconv.Quote(key)}
+			decls := []string{}
+			fields := []string{}
+			for _, p := range params {
+				decls = append(decls, p.Name+" "+p.Type)
+				if p.Field != "" {
+					fields = append(fields, strconv.Quote(p.Field)+": "+p.Name)
+				} else {
+					args = append(args, p.Name)
+				}
+			}
+			if len(fields) > 0 {
+				args = append(args, "map[string]any{"+strings.Join(fields, ", ")+"}")
+			}
+
+			fmt.Fprintf(&buf, "\n// %s returns the message %s of the %s bundle\n", name, strconv.Quote(key), bundle)
+			fmt.Fprintf(&buf, "func %s(%s) types.Stringer {\n", name, strings.Join(decls, ", "))
+			fmt.Fprintf(&buf, "\treturn %s.Str(%s)\n}\n", bundleVar, strings.Join(args, ", "))
+		}
+
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			return err
+		}
+
+		fileName := uniqueName(fileNames, strings.ToLower(identifier(bundle, "bundle")), "") + ".go"
+		if err := os.WriteFile(filepath.Join(outputDir, fileName), src, 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// accessorParams 根据消息中的模板字段或 printf 动词推断参数名和类型，参数名不使用 reserved 中的名称
+func accessorParams(msg string, reserved ...string) []accessorParam {
+	params := []accessorParam{}
+	names := map[string]bool{}
+	for _, name := range reserved {
+		names[name] = true
+	}
+
+	// 模板消息使用字段名作为参数名
+	if matches := templateFieldRegexp.FindAllStringSubmatch(msg, -1); len(matches) > 0 {
+		fields := map[string]bool{}
+		for _, m := range matches {
+			field := m[1]
+			if fields[field] {
+				continue
+			}
+			fields[field] = true
+			params = append(params, accessorParam{Name: uniqueName(names, paramName(field), ""), Type: "any", Field: field})
+		}
+		return params
+	}
+
+	// printf 动词: 参数名取动词前的单词，类型由动词决定
+	argTypes := map[int]string{}
+	words := map[int]string{}
+	argNum := 0
+	for i := 0; i < len(msg); i++ {
+		if msg[i] != '%' {
+			continue
+		}
+		i++
+
+		// 标志、宽度和精度
+		for i < len(msg) && strings.IndexByte("+-# 0123456789.*[]", msg[i]) >= 0 {
+			switch msg[i] {
+			case '*':
+				argTypes[argNum] = "int"
+				argNum++
+			case '[':
+				// 显式参数索引，如 %[2]s
+				end := strings.IndexByte(msg[i:], ']')
+				if end < 0 {
+					break
+				}
+				if n, err := strconv.Atoi(msg[i+1 : i+end]); err == nil && n > 0 {
+					argNum = n - 1
+				}
+				i += end
+			}
+			i++
+		}
+		if i >= len(msg) || msg[i] == '%' {
+			continue
+		}
+
+		argTypes[argNum] = verbType(msg[i])
+		if _, ok := words[argNum]; !ok {
+			words[argNum] = precedingWord(msg[:strings.LastIndexByte(msg[:i], '%')])
+		}
+		argNum++
+	}
+
+	for n := 0; n < len(argTypes); n++ {
+		typ, ok := argTypes[n]
+		if !ok {
+			typ = "any"
+		}
+		name := words[n]
+		if name == "" {
+			name = "arg" + strconv.Itoa(n+1)
+		}
+		params = append(params, accessorParam{Name: uniqueName(names, paramName(name), ""), Type: typ})
+	}
+
+	return params
+}
+
+// verbType 返回 printf 动词对应的参数类型
+func verbType(verb byte) string {
+	switch verb {
+	case 's', 'q':
+		return "string"
+	case 'd', 'b', 'o', 'c', 'U':
+		return "int"
+	case 'e', 'E', 'f', 'F', 'g', 'G':
+		return "float64"
+	case 't':
+		return "bool"
+	default:
+		return "any"
+	}
+}
+
+// precedingWord 返回文本末尾的单词，用作参数名
+func precedingWord(s string) string {
+	s = strings.TrimRightFunc(s, unicode.IsSpace)
+	end := len(s)
+	start := strings.LastIndexFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) + 1
+	if start >= end {
+		return ""
+	}
+	return s[start:end]
+}
+
+// identifier 将文本转换为导出的 Go 标识符，如 "User %s not exist" -> "UserNotExist"，
+// 结果不以大写字母开头时添加前缀 prefix
+func identifier(s, prefix string) string {
+	var b strings.Builder
+
+	// 去掉 printf 动词和模板动作
+	s = templateActionRegexp.ReplaceAllString(s, " ")
+	s = printfVerbRegexp.ReplaceAllString(s, " ")
+
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		if i >= 8 {
+			break // 避免过长的名称
+		}
+		r := []rune(w)
+		r[0] = unicode.ToUpper(r[0])
+		b.WriteString(string(r))
+	}
+
+	name := b.String()
+	if name == "" || !unicode.IsUpper([]rune(name)[0]) {
+		name = prefix + name
+	}
+	return name
+}
+
+// paramName 将名称转换为参数名，避免与关键字冲突
+func paramName(name string) string {
+	name = lowerFirst(identifier(name, "arg"))
+	if token.IsKeyword(name) || name == "i18n" || name == "types" {
+		name += "Arg"
+	}
+	return name
+}
+
+// lowerFirst 将首字母转换为小写
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+// uniqueName 返回未使用的名称并标记为已使用，冲突时依次尝试添加前缀和数字后缀
+func uniqueName(used map[string]bool, name, prefix string) string {
+	candidate := name
+	if used[candidate] && prefix != "" {
+		candidate = prefix + name
+	}
+	for i := 2; used[candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	used[candidate] = true
+	return candidate
+}
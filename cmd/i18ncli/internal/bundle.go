@@ -1,10 +1,14 @@
 package internal
 
+import i18nInternal "github.com/epkgs/i18n/internal"
+
 // Bundle 存储bundle使用信息
 type Bundle struct {
-	Name  string              // bundle名称
-	Trans map[string]struct{} // 翻译键集合，使用map[string]struct{}提高效率
-	Vars  map[string]*VarInfo // 变量信息映射，PackagePath:VarName -> VarInfo
+	Name    string                       // bundle名称
+	Trans   map[string]struct{}          // 翻译键集合，使用map[string]struct{}提高效率
+	Vars    map[string]*VarInfo          // 变量信息映射，PackagePath:VarName -> VarInfo
+	Parents []string                     // 父bundle名称，通过 i18n.Extends("common") 声明
+	Defined map[string]map[string]string // 通过 Define 声明的内置翻译，键 -> 语言 -> 翻译
 }
 
 // VarInfo 存储变量的详细信息
@@ -16,9 +20,10 @@ type VarInfo struct {
 
 func NewBundle(name string) *Bundle {
 	return &Bundle{
-		Name:  name,
-		Trans: make(map[string]struct{}),
-		Vars:  make(map[string]*VarInfo),
+		Name:    name,
+		Trans:   make(map[string]struct{}),
+		Vars:    make(map[string]*VarInfo),
+		Defined: make(map[string]map[string]string),
 	}
 }
 
@@ -33,3 +38,36 @@ func (b *Bundle) AddVarDefine(varName, pkg, filePath string) {
 func (b *Bundle) AddTrans(key string) {
 	b.Trans[key] = struct{}{}
 }
+
+// AddDefine 添加通过 Define 声明的键及其内置翻译，语言代码会被规范化
+func (b *Bundle) AddDefine(key string, text map[string]string) {
+	b.AddTrans(key)
+
+	if b.Defined[key] == nil {
+		b.Defined[key] = map[string]string{}
+	}
+	for lang, txt := range text {
+		if tag, err := i18nInternal.NormalizeLanguage(lang); err == nil {
+			b.Defined[key][tag.String()] = txt
+		}
+	}
+}
+
+// AddParents 添加父bundle，忽略重复项
+func (b *Bundle) AddParents(parents ...string) {
+	for _, parent := range parents {
+		if parent == b.Name {
+			continue
+		}
+		exists := false
+		for _, p := range b.Parents {
+			if p == parent {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			b.Parents = append(b.Parents, parent)
+		}
+	}
+}
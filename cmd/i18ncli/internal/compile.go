@@ -0,0 +1,149 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	i18nInternal "github.com/epkgs/i18n/internal"
+)
+
+// CatalogFileName 编译生成的 Go 文件名
+const CatalogFileName = "catalog.go"
+
+// CompileTranslationFiles 将资源目录下的翻译文件编译为 Go 代码，生成的包以 map 字面量保存翻译，
+// 导入时通过 i18n.AddCompiled 注册到默认实例，默认实例不再查找翻译目录，启动时无需读取和反序列化翻译文件。
+// 编译只检查模板消息的语法，模板仍在运行时首次使用时解析，复数形式也在运行时选择
+func (g *Generator) CompileTranslationFiles(resDir, outDir string) error {
+	resourceDir := g.resourceDir(resDir)
+	outputDir := g.resourceDir(outDir)
+
+	catalog, err := g.readCatalog(resourceDir)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by i18ncli. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", packageName(outputDir))
+	fmt.Fprintf(&buf, "import \"github.com/epkgs/i18n\"\n\n")
+	fmt.Fprintf(&buf, "// Catalog contains the compiled translations, by bundle name, language and key.\n")
+	fmt.Fprintf(&buf, "// The template messages are parsed on first use.\n")
+	fmt.Fprintf(&buf, "var Catalog = i18n.Catalog{\n")
+	for _, bundle := range sortedKeys(catalog) {
+		fmt.Fprintf(&buf, "%s: {\n", strconv.Quote(bundle))
+		for _, lang := range sortedKeys(catalog[bundle]) {
+			fmt.Fprintf(&buf, "%s: {\n", strconv.Quote(lang))
+			for _, key := range sortedKeys(catalog[bundle][lang]) {
+				fmt.Fprintf(&buf, "%s: %s,\n", strconv.Quote(key), strconv.Quote(catalog[bundle][lang][key]))
+			}
+			fmt.Fprintf(&buf, "},\n")
+		}
+		fmt.Fprintf(&buf, "},\n")
+	}
+	fmt.Fprintf(&buf, "}\n\n")
+	fmt.Fprintf(&buf, "func init() {\n\ti18n.AddCompiled(Catalog)\n}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(outputDir, CatalogFileName), src, 0644)
+}
+
+// readCatalog 读取资源目录下的翻译文件，返回 bundle名称 -> 语言 -> 键 -> 翻译
+func (g *Generator) readCatalog(resourceDir string) (map[string]map[string]map[string]string, error) {
+	layoutPattern := g.Layout
+	if layoutPattern == "" {
+		layoutPattern = DefaultLayout
+	}
+
+	layout, err := i18nInternal.CompileLayout(layoutPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	// bundle名称 -> 语言 -> 键 -> 翻译
+	catalog := map[string]map[string]map[string]string{}
+
+	err = filepath.WalkDir(resourceDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		ext := filepath.Ext(path)
+		if !i18nInternal.Includes(localeExts, ext) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(resourceDir, path)
+		if err != nil {
+			return err
+		}
+
+		lang, bundle, ok := layout.Match(filepath.ToSlash(rel))
+		if !ok {
+			return nil
+		}
+
+		tag, err := i18nInternal.NormalizeLanguage(lang)
+		if err != nil {
+			return nil
+		}
+
+		for key, value := range readTranslationKeys(path, strings.TrimPrefix(ext, ".")) {
+			str, ok := value.(string)
+			if !ok {
+				continue
+			}
+
+			// 模板消息在编译时检查
+			if strings.Contains(str, "{{") {
+				if _, err := template.New(key).Parse(str); err != nil {
+					return fmt.Errorf("%s: invalid template %q: %w", path, key, err)
+				}
+			}
+
+			if catalog[bundle] == nil {
+				catalog[bundle] = map[string]map[string]string{}
+			}
+			if catalog[bundle][tag.String()] == nil {
+				catalog[bundle][tag.String()] = map[string]string{}
+			}
+			catalog[bundle][tag.String()][key] = str
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(catalog) == 0 {
+		return nil, fmt.Errorf("no translation files found in %s", resourceDir)
+	}
+
+	return catalog, nil
+}
+
+// sortedKeys 返回排序后的键
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,95 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	i18nInternal "github.com/epkgs/i18n/internal"
+)
+
+// EmbedFileName 生成的 go:embed 文件名
+const EmbedFileName = "embed.go"
+
+// localeExts 可嵌入的翻译文件扩展名
+var localeExts = []string{".json", ".yaml", ".yml", ".toml", ".tml", ".ini"}
+
+// GenerateEmbedFile 在资源目录下生成 go:embed 文件，导出包含所有翻译文件的 FS 变量，
+// 可通过 i18n.NewEmbed(FS, ".") 使用
+func (g *Generator) GenerateEmbedFile(resDir string) error {
+	resourceDir := g.resourceDir(resDir)
+
+	entries, err := os.ReadDir(resourceDir)
+	if err != nil {
+		return err
+	}
+
+	// 语言目录（或其他目录）整体嵌入，根目录下只嵌入翻译文件
+	patterns := []string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+			continue
+		}
+		if entry.IsDir() || i18nInternal.Includes(localeExts, filepath.Ext(name)) {
+			patterns = append(patterns, name)
+		}
+	}
+
+	if len(patterns) == 0 {
+		return fmt.Errorf("no translation files found in %s", resourceDir)
+	}
+
+	sort.Strings(patterns)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by i18ncli. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", packageName(resourceDir))
+	fmt.Fprintf(&buf, "import \"embed\"\n\n")
+	fmt.Fprintf(&buf, "// FS contains the translation files, use it with i18n.NewEmbed(FS, \".\")\n")
+	fmt.Fprintf(&buf, "//\n//go:embed %s\n", strings.Join(patterns, " "))
+	fmt.Fprintf(&buf, "var FS embed.FS\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(resourceDir, EmbedFileName), src, 0644)
+}
+
+// packageName 返回目录中已有 Go 文件的包名，没有时根据目录名生成
+func packageName(dir string) string {
+	files, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.PackageClauseOnly)
+		if err == nil {
+			return f.Name.Name
+		}
+	}
+
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		}
+		return -1
+	}, filepath.Base(dir))
+
+	if name == "" || name[0] >= '0' && name[0] <= '9' {
+		return "locales"
+	}
+
+	return name
+}
@@ -0,0 +1,70 @@
+package internal
+
+import (
+	"io/fs"
+	"path/filepath"
+
+	i18nInternal "github.com/epkgs/i18n/internal"
+	"golang.org/x/text/language"
+)
+
+// translationFiles 按路径模式定位资源目录下的翻译文件
+type translationFiles struct {
+	resourceDir string
+	layout      *i18nInternal.Layout
+	fileType    string
+	langs       map[language.Tag]struct{} // 已存在的翻译文件的语言
+	existing    map[string]string         // 语言 + bundle名称 -> 已存在的翻译文件路径
+}
+
+func newTranslationFiles(resourceDir string, layout *i18nInternal.Layout, fileType string) *translationFiles {
+	files := &translationFiles{
+		resourceDir: resourceDir,
+		layout:      layout,
+		fileType:    fileType,
+		langs:       map[language.Tag]struct{}{},
+		existing:    map[string]string{},
+	}
+
+	// 扫描资源目录，记录符合路径模式的翻译文件
+	filepath.WalkDir(resourceDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(resourceDir, path)
+		if err != nil {
+			return nil
+		}
+
+		lang, bundle, ok := layout.Match(filepath.ToSlash(rel))
+		if !ok {
+			return nil
+		}
+
+		tag, err := i18nInternal.NormalizeLanguage(lang)
+		if err != nil {
+			return nil
+		}
+
+		files.langs[tag] = struct{}{}
+
+		// 仅复用与输出格式一致的文件
+		if filepath.Ext(path) == "."+fileType {
+			files.existing[tag.String()+"/"+bundle] = path
+		}
+
+		return nil
+	})
+
+	return files
+}
+
+// path 返回语言和bundle对应的翻译文件路径，优先使用已存在的文件
+func (f *translationFiles) path(lang language.Tag, bundle string) string {
+	if path, ok := f.existing[lang.String()+"/"+bundle]; ok {
+		return path
+	}
+
+	return filepath.Join(f.resourceDir, filepath.FromSlash(f.layout.Path(lang.String(), bundle, f.fileType)))
+}
@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	"github.com/BurntSushi/toml"
+	i18nInternal "github.com/epkgs/i18n/internal"
 	"github.com/iancoleman/orderedmap"
 	"golang.org/x/text/language"
 	"gopkg.in/yaml.v3"
@@ -23,8 +24,12 @@ type Generator struct {
 	Module    string             // module name
 	ModuleDir string             // module directory (go.mod path)
 	Bundles   map[string]*Bundle // name => bundle
+	Layout    string             // 翻译文件路径模式，如 "{bundle}/{lang}.yaml"，为空时使用 DefaultLayout
 }
 
+// DefaultLayout 默认的翻译文件路径模式: 资源目录/语言/bundle.扩展名
+const DefaultLayout = "{lang}/{bundle}.*"
+
 // ParsedFile 存储已解析的文件信息
 type ParsedFile struct {
 	FilePath  string
@@ -70,25 +75,43 @@ func (g *Generator) Walk() error {
 
 func (g *Generator) GenerateTranslationFiles(fileType, resDir string, langs ...string) error {
 
-	var resourceDir string
-	if filepath.IsAbs(resDir) {
-		resourceDir = resDir
-	} else {
-		resourceDir = filepath.Join(g.BaseDir, resDir)
+	resourceDir := g.resourceDir(resDir)
+
+	layoutPattern := g.Layout
+	if layoutPattern == "" {
+		layoutPattern = DefaultLayout
+	}
+
+	layout, err := i18nInternal.CompileLayout(layoutPattern)
+	if err != nil {
+		return err
 	}
 
+	files := newTranslationFiles(resourceDir, layout, fileType)
+
 	langMap := map[language.Tag]struct{}{}
 	for _, lang := range langs {
-		tag := language.Make(lang)
+		tag, err := i18nInternal.NormalizeLanguage(lang)
+		if err != nil {
+			return fmt.Errorf("invalid language %q: %w", lang, err)
+		}
 		langMap[tag] = struct{}{}
 	}
 
-	if rd, err := os.ReadDir(resourceDir); err == nil {
-		for _, f := range rd {
-			if f.IsDir() {
-				folder := f.Name()
-				tag := language.Make(folder)
-				langMap[tag] = struct{}{}
+	// 已存在的翻译文件的语言
+	for lang := range files.langs {
+		langMap[lang] = struct{}{}
+	}
+
+	// 默认路径模式下，语言目录即使为空也视为已有语言
+	if g.Layout == "" {
+		if rd, err := os.ReadDir(resourceDir); err == nil {
+			for _, f := range rd {
+				if f.IsDir() {
+					if tag, err := i18nInternal.NormalizeLanguage(f.Name()); err == nil {
+						langMap[tag] = struct{}{}
+					}
+				}
 			}
 		}
 	}
@@ -114,15 +137,10 @@ func (g *Generator) GenerateTranslationFiles(fileType, resDir string, langs ...s
 	}
 
 	for lang := range langMap {
-		langDir := filepath.Join(resourceDir, lang.String())
-		if err := os.MkdirAll(langDir, 0755); err != nil {
-			log.Printf("[ERROR] create dir %s: %v", langDir, err)
-			continue // 忽略错误
-		}
 
 		for _, bundle := range g.Bundles {
 
-			filePath := filepath.Join(langDir, bundle.Name+"."+fileType)
+			filePath := files.path(lang, bundle.Name)
 
 			// Check if file exists
 			translations := orderedmap.New()
@@ -134,13 +152,25 @@ func (g *Generator) GenerateTranslationFiles(fileType, resDir string, langs ...s
 				}
 			}
 
+			// 父bundle中已有的键，不重复添加
+			inherited := g.inheritedKeys(bundle, lang, files)
+
 			// changed mark
 			changed := false
 			// Add format strings as both keys and values
 			for txt := range bundle.Trans {
+				if _, isInherited := inherited[txt]; isInherited {
+					continue
+				}
 				// Only add if not already present
 				if _, exists := translations.Get(txt); !exists {
-					translations.Set(txt, txt)
+					// defined messages get their built-in translation,
+					// pseudo locales get the pseudo localized text, the others the text itself
+					if defined, isDefined := bundle.Defined[txt][lang.String()]; isDefined {
+						translations.Set(txt, defined)
+					} else {
+						translations.Set(txt, i18nInternal.Pseudo(lang, txt))
+					}
 					changed = true // Mark as changed
 				}
 			}
@@ -156,6 +186,11 @@ func (g *Generator) GenerateTranslationFiles(fileType, resDir string, langs ...s
 				continue
 			}
 
+			if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+				log.Printf("[ERROR] create dir %s: %v", filepath.Dir(filePath), err)
+				continue // 忽略错误
+			}
+
 			if err := os.WriteFile(filePath, data, 0644); err != nil {
 				log.Printf("[ERROR] write file %s: %v", filePath, err)
 				continue
@@ -168,6 +203,44 @@ func (g *Generator) GenerateTranslationFiles(fileType, resDir string, langs ...s
 	return nil
 }
 
+// resourceDir 返回资源目录的路径，相对路径基于 BaseDir
+func (g *Generator) resourceDir(resDir string) string {
+	if filepath.IsAbs(resDir) {
+		return resDir
+	}
+	return filepath.Join(g.BaseDir, resDir)
+}
+
+// inheritedKeys 返回bundle的所有祖先bundle中的翻译键，包括源码中收集到的键和已存在的翻译文件中的键
+func (g *Generator) inheritedKeys(bundle *Bundle, lang language.Tag, files *translationFiles) map[string]struct{} {
+	keys := map[string]struct{}{}
+	visited := map[string]bool{bundle.Name: true}
+
+	var walk func(b *Bundle)
+	walk = func(b *Bundle) {
+		for _, parentName := range b.Parents {
+			if visited[parentName] {
+				continue
+			}
+			visited[parentName] = true
+
+			for key := range readTranslationKeys(files.path(lang, parentName), files.fileType) {
+				keys[key] = struct{}{}
+			}
+
+			if parent, ok := g.Bundles[parentName]; ok {
+				for key := range parent.Trans {
+					keys[key] = struct{}{}
+				}
+				walk(parent)
+			}
+		}
+	}
+	walk(bundle)
+
+	return keys
+}
+
 func (g *Generator) collectBundles(f *ParsedFile) {
 	ast.Inspect(f.Ast, func(n ast.Node) bool {
 		switch stmt := n.(type) {
@@ -184,6 +257,7 @@ func (g *Generator) collectBundles(f *ParsedFile) {
 							if bundleName := extractBundleName(callExpr, f.I18nAlias); bundleName != "" {
 								// 将变量信息添加到bundle中
 								bundle := g.getBundleOrNew(bundleName)
+								bundle.AddParents(extractBundleParents(callExpr, f.I18nAlias)...)
 								pkg, _ := g.getFullPkgPath(filepath.Dir(f.FilePath))
 								bundle.AddVarDefine(ident.Name, pkg, f.FilePath)
 							}
@@ -213,6 +287,7 @@ func (g *Generator) collectBundles(f *ParsedFile) {
 									if bundleName := extractBundleName(callExpr, f.I18nAlias); bundleName != "" {
 										// 将变量信息添加到bundle中
 										bundle := g.getBundleOrNew(bundleName)
+										bundle.AddParents(extractBundleParents(callExpr, f.I18nAlias)...)
 										pkg, _ := g.getFullPkgPath(filepath.Dir(f.FilePath))
 										bundle.AddVarDefine(name.Name, pkg, f.FilePath)
 									}
@@ -244,13 +319,19 @@ func (g *Generator) collectBundleTranslations(f *ParsedFile) {
 			if selectorExpr, isSelector := callExpr.Fun.(*ast.SelectorExpr); isSelector {
 				methodName := selectorExpr.Sel.Name
 
-				// 检查是否是 Str 或 Err 方法调用
-				if methodName == "Str" || methodName == "Err" {
+				// 检查是否是 Str、Err、HTML 或 Define 方法调用
+				if methodName == "Str" || methodName == "Err" || methodName == "HTML" || methodName == "Define" {
+					addBundleStr := g.addBundleStr
+					if methodName == "Define" {
+						addBundleStr = g.addBundleDefine
+					}
+
 					// 检查是否是 i18n.Bundle().Str() 形式（直接链式调用）
 					if funCall, isFunCall := selectorExpr.X.(*ast.CallExpr); isFunCall {
 						if bundleName := extractBundleName(funCall, f.I18nAlias); bundleName != "" {
 							bundle := g.getBundleOrNew(bundleName)
-							g.addBundleStr(bundle, callExpr)
+							bundle.AddParents(extractBundleParents(funCall, f.I18nAlias)...)
+							addBundleStr(bundle, callExpr)
 						}
 						return true
 					}
@@ -259,7 +340,7 @@ func (g *Generator) collectBundleTranslations(f *ParsedFile) {
 					if ident, isIdent := selectorExpr.X.(*ast.Ident); isIdent {
 						// 首先在当前包中查找变量
 						if bundle, err := g.getBundleByVar(f.Pkg, ident.Name); err == nil {
-							g.addBundleStr(bundle, callExpr)
+							addBundleStr(bundle, callExpr)
 						}
 						return true
 					}
@@ -269,7 +350,7 @@ func (g *Generator) collectBundleTranslations(f *ParsedFile) {
 						if xIdent, isXIdent := selector.X.(*ast.Ident); isXIdent {
 							pkgPath := findPkgByID(f.Ast, xIdent.Name)
 							if bundle, err := g.getBundleByVar(pkgPath, selector.Sel.Name); err == nil {
-								g.addBundleStr(bundle, callExpr)
+								addBundleStr(bundle, callExpr)
 							}
 						}
 					}
@@ -282,6 +363,7 @@ func (g *Generator) collectBundleTranslations(f *ParsedFile) {
 					if funCall, isFunCall := selectorExpr.X.(*ast.CallExpr); isFunCall {
 						if bundleName := extractBundleName(funCall, f.I18nAlias); bundleName != "" {
 							bundle := g.getBundleOrNew(bundleName)
+							bundle.AddParents(extractBundleParents(funCall, f.I18nAlias)...)
 							g.addBundleNStrs(bundle, callExpr)
 						}
 						return true
@@ -409,6 +491,33 @@ func (g *Generator) addBundleStr(b *Bundle, callExpr *ast.CallExpr) {
 	}
 }
 
+// addBundleDefine 收集 bundle.Define("key", i18n.Text{"en": "..."}) 声明的键和内置翻译
+func (g *Generator) addBundleDefine(b *Bundle, callExpr *ast.CallExpr) {
+	key := getCallArgString(callExpr, 0)
+	if key == "" {
+		return
+	}
+
+	text := map[string]string{}
+	if len(callExpr.Args) > 1 {
+		if lit, isLit := callExpr.Args[1].(*ast.CompositeLit); isLit {
+			for _, elt := range lit.Elts {
+				kv, isKV := elt.(*ast.KeyValueExpr)
+				if !isKV {
+					continue
+				}
+				langLit, isLangLit := kv.Key.(*ast.BasicLit)
+				txtLit, isTxtLit := kv.Value.(*ast.BasicLit)
+				if isLangLit && isTxtLit && langLit.Kind == token.STRING && txtLit.Kind == token.STRING {
+					text[unquote(langLit.Value)] = unquote(txtLit.Value)
+				}
+			}
+		}
+	}
+
+	b.AddDefine(key, text)
+}
+
 func (g *Generator) addBundleNStrs(b *Bundle, callExpr *ast.CallExpr) {
 	// singular
 	if singular := getCallArgString(callExpr, 1); singular != "" {
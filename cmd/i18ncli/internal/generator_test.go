@@ -2,8 +2,11 @@
 package internal
 
 import (
+	"go/parser"
+	"go/token"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -214,3 +217,428 @@ func TestAddBundleStr(t *testing.T) {
 		t.Error("Expected empty translations initially")
 	}
 }
+
+func TestGeneratorGeneratePseudoTranslationFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	resDir := "locales"
+
+	goModContent := `module test/module
+
+	go 1.21
+	`
+	err := os.WriteFile(filepath.Join(tempDir, "go.mod"), []byte(goModContent), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gen := NewGenerator(tempDir)
+
+	bundle := gen.getBundleOrNew("user")
+	bundle.AddTrans("User %s not exist")
+
+	err = gen.GenerateTranslationFiles("json", resDir, "en-XA")
+	if err != nil {
+		t.Errorf("GenerateTranslationFiles failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tempDir, resDir, "en-XA", "user.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 伪本地化文本应保留占位符
+	expected := `"User %s not exist": "[Ûšéŕ %s ñöţ éẋîšţ ~~~~~]"`
+	if !strings.Contains(string(content), expected) {
+		t.Errorf("Expected %s in pseudo locale file, got %s", expected, content)
+	}
+}
+
+func TestGeneratorSkipInheritedKeys(t *testing.T) {
+	tempDir := t.TempDir()
+	resDir := "locales"
+
+	goModContent := `module test/module
+
+	go 1.21
+	`
+	err := os.WriteFile(filepath.Join(tempDir, "go.mod"), []byte(goModContent), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// admin 继承 common，两者都使用了 "Save"
+	testGoFile := `
+	package main
+
+	import "github.com/epkgs/i18n"
+
+	var common = i18n.Bundle("common")
+	var admin = i18n.Bundle("admin", i18n.Extends("common"))
+
+	func main() {
+		common.Str("Save")
+		admin.Str("Save")
+		admin.Str("Delete user %s", "alice")
+	}
+	`
+
+	err = os.WriteFile(filepath.Join(tempDir, "main.go"), []byte(testGoFile), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gen := NewGenerator(tempDir)
+	if err := gen.Walk(); err != nil {
+		t.Fatalf("Walk failed: %v", err)
+	}
+
+	admin, exists := gen.Bundles["admin"]
+	if !exists {
+		t.Fatal("Expected 'admin' bundle to be created")
+	}
+
+	if len(admin.Parents) != 1 || admin.Parents[0] != "common" {
+		t.Errorf("Expected admin parents [common], got %v", admin.Parents)
+	}
+
+	if err := gen.GenerateTranslationFiles("json", resDir, "en"); err != nil {
+		t.Fatalf("GenerateTranslationFiles failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tempDir, resDir, "en", "admin.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 继承的键不应重复写入 admin.json
+	if strings.Contains(string(content), `"Save"`) {
+		t.Errorf("Expected inherited key to be skipped, got %s", content)
+	}
+
+	if !strings.Contains(string(content), `"Delete user %s"`) {
+		t.Errorf("Expected own key to be written, got %s", content)
+	}
+}
+
+func TestGeneratorGenerateTranslationFilesWithLayout(t *testing.T) {
+	tempDir := t.TempDir()
+	resDir := "locales"
+
+	goModContent := `module test/module
+
+	go 1.21
+	`
+	err := os.WriteFile(filepath.Join(tempDir, "go.mod"), []byte(goModContent), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 已存在的嵌套翻译文件
+	existingFile := filepath.Join(tempDir, resDir, "admin", "order", "zh-CN.json")
+	if err := os.MkdirAll(filepath.Dir(existingFile), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(existingFile, []byte(`{"Order": "订单"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	gen := NewGenerator(tempDir)
+	gen.Layout = "**/{bundle}/{lang}.json"
+
+	gen.getBundleOrNew("user").AddTrans("Hello, world!")
+	gen.getBundleOrNew("order").AddTrans("Order")
+	gen.getBundleOrNew("order").AddTrans("Cancel")
+
+	if err := gen.GenerateTranslationFiles("json", resDir, "en"); err != nil {
+		t.Fatalf("GenerateTranslationFiles failed: %v", err)
+	}
+
+	// 新文件按路径模式创建，语言包括已存在文件的语言
+	for _, file := range []string{"user/en.json", "user/zh-CN.json", "order/en.json"} {
+		if _, err := os.Stat(filepath.Join(tempDir, resDir, file)); err != nil {
+			t.Errorf("Expected %s to be created: %v", file, err)
+		}
+	}
+
+	// 已存在的文件应原地更新
+	content, err := os.ReadFile(existingFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), `"Order": "订单"`) || !strings.Contains(string(content), `"Cancel"`) {
+		t.Errorf("Expected existing file to be updated in place, got %s", content)
+	}
+}
+
+func TestGeneratorGenerateEmbedFile(t *testing.T) {
+	tempDir := t.TempDir()
+	resDir := "locales"
+
+	goModContent := `module test/module
+
+	go 1.21
+	`
+	err := os.WriteFile(filepath.Join(tempDir, "go.mod"), []byte(goModContent), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gen := NewGenerator(tempDir)
+	gen.getBundleOrNew("user").AddTrans("Hello, world!")
+
+	if err := gen.GenerateTranslationFiles("json", resDir, "en", "zh-CN"); err != nil {
+		t.Fatalf("GenerateTranslationFiles failed: %v", err)
+	}
+
+	// 资源目录中已有的 Go 文件决定包名
+	bundleFile := filepath.Join(tempDir, resDir, "user.go")
+	if err := os.WriteFile(bundleFile, []byte("package translations\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := gen.GenerateEmbedFile(resDir); err != nil {
+		t.Fatalf("GenerateEmbedFile failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tempDir, resDir, EmbedFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{"package translations", "//go:embed en zh-CN", "var FS embed.FS"} {
+		if !strings.Contains(string(content), expected) {
+			t.Errorf("Expected %s in embed file, got %s", expected, content)
+		}
+	}
+}
+
+func TestGeneratorCompileTranslationFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	resDir := "locales"
+
+	goModContent := `module test/module
+
+	go 1.21
+	`
+	err := os.WriteFile(filepath.Join(tempDir, "go.mod"), []byte(goModContent), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"en/user.json":    `{"Hello %s": "Hello %s"}`,
+		"zh_CN/user.yaml": `"Hello %s": "你好 %s"`,
+	}
+	for file, content := range files {
+		path := filepath.Join(tempDir, resDir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gen := NewGenerator(tempDir)
+	if err := gen.CompileTranslationFiles(resDir, "compiled"); err != nil {
+		t.Fatalf("CompileTranslationFiles failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tempDir, "compiled", CatalogFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 语言代码应被规范化
+	for _, expected := range []string{"package compiled", `"zh-CN": {`, `"Hello %s": "你好 %s",`, "i18n.AddCompiled(Catalog)"} {
+		if !strings.Contains(string(content), expected) {
+			t.Errorf("Expected %s in catalog file, got %s", expected, content)
+		}
+	}
+
+	// 无效的模板消息应报错
+	if err := os.WriteFile(filepath.Join(tempDir, resDir, "en", "user.json"), []byte(`{"Hi {{.Name}}": "Hi {{.Name"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.CompileTranslationFiles(resDir, "compiled"); err == nil {
+		t.Error("Expected invalid template to fail")
+	}
+}
+
+func TestGeneratorGenerateAccessors(t *testing.T) {
+	tempDir := t.TempDir()
+	resDir := "locales"
+
+	goModContent := `module test/module
+
+	go 1.21
+	`
+	err := os.WriteFile(filepath.Join(tempDir, "go.mod"), []byte(goModContent), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"en/user.json":    `{"User %s not exist": "User %s does not exist", "Welcome {{.Name}}": "Welcome {{.Name}}"}`,
+		"zh-CN/user.json": `{"%d items in %.1f seconds": "%.1f 秒内 %d 个项目"}`,
+	}
+	for file, content := range files {
+		path := filepath.Join(tempDir, resDir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gen := NewGenerator(tempDir)
+	if err := gen.GenerateAccessors(resDir, "msgs"); err != nil {
+		t.Fatalf("GenerateAccessors failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tempDir, "msgs", "user.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 参数名和类型由 printf 动词和模板字段推断，所有语言的键都会生成
+	for _, expected := range []string{
+		"package msgs",
+		`var userBundle = i18n.Bundle("user")`,
+		"func UserNotExist(user string) types.Stringer {",
+		`return userBundle.Str("User %s not exist", user)`,
+		"func Welcome(name any) types.Stringer {",
+		`return userBundle.Str("Welcome {{.Name}}", map[string]any{"Name": name})`,
+		"func ItemsInSeconds(arg1 int, in float64) types.Stringer {",
+	} {
+		if !strings.Contains(string(content), expected) {
+			t.Errorf("Expected %s in accessor file, got %s", expected, content)
+		}
+	}
+}
+
+func TestGeneratorGenerateAccessorsSameIdentifier(t *testing.T) {
+	tempDir := t.TempDir()
+	resDir := "locales"
+
+	goModContent := `module test/module
+
+	go 1.21
+	`
+	if err := os.WriteFile(filepath.Join(tempDir, "go.mod"), []byte(goModContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"en/user-admin.json": `{"Users": "Users"}`,
+		"en/user_admin.json": `{"Users": "Users", "Roles": "Roles"}`,
+	}
+	for file, content := range files {
+		path := filepath.Join(tempDir, resDir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gen := NewGenerator(tempDir)
+	if err := gen.GenerateAccessors(resDir, "msgs"); err != nil {
+		t.Fatalf("GenerateAccessors failed: %v", err)
+	}
+
+	// 标识符相同的 bundle 使用不同的变量名和文件名
+	expected := map[string][]string{
+		"useradmin.go": {
+			`var userAdminBundle = i18n.Bundle("user-admin")`,
+			"func Users() types.Stringer {",
+		},
+		"useradmin2.go": {
+			`var userAdminBundle2 = i18n.Bundle("user_admin")`,
+			`return userAdminBundle2.Str("Users")`,
+			"func Roles() types.Stringer {",
+		},
+	}
+	for file, contents := range expected {
+		content, err := os.ReadFile(filepath.Join(tempDir, "msgs", file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range contents {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("Expected %s in %s, got %s", want, file, content)
+			}
+		}
+	}
+
+	// 包级别的名称不重复
+	declared := map[string]bool{}
+	for file := range expected {
+		f, err := parser.ParseFile(token.NewFileSet(), filepath.Join(tempDir, "msgs", file), nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for name := range f.Scope.Objects {
+			if declared[name] {
+				t.Errorf("%s is declared twice", name)
+			}
+			declared[name] = true
+		}
+	}
+}
+
+func TestGeneratorExtractDefine(t *testing.T) {
+	tempDir := t.TempDir()
+	resDir := "locales"
+
+	goModContent := `module test/module
+
+	go 1.21
+	`
+	err := os.WriteFile(filepath.Join(tempDir, "go.mod"), []byte(goModContent), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testGoFile := `
+	package main
+
+	import "github.com/epkgs/i18n"
+
+	var errs = i18n.Bundle("errors")
+
+	var NotFound = errs.Define("not_found", i18n.Text{"en": "Not found", "zh_CN": "未找到"})
+	`
+
+	err = os.WriteFile(filepath.Join(tempDir, "main.go"), []byte(testGoFile), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gen := NewGenerator(tempDir)
+	if err := gen.Walk(); err != nil {
+		t.Fatalf("Walk failed: %v", err)
+	}
+
+	if err := gen.GenerateTranslationFiles("json", resDir, "en", "zh-CN", "fr"); err != nil {
+		t.Fatalf("GenerateTranslationFiles failed: %v", err)
+	}
+
+	// 内置翻译应导出到对应语言的翻译文件，其他语言使用键本身
+	expected := map[string]string{
+		"en":    `"not_found": "Not found"`,
+		"zh-CN": `"not_found": "未找到"`,
+		"fr":    `"not_found": "not_found"`,
+	}
+	for lang, kv := range expected {
+		content, err := os.ReadFile(filepath.Join(tempDir, resDir, lang, "errors.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(content), kv) {
+			t.Errorf("Expected %s in %s translation file, got %s", kv, lang, content)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"encoding/json"
 	"fmt"
 	"go/ast"
 	"go/token"
@@ -9,7 +10,10 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/BurntSushi/toml"
+	i18nInternal "github.com/epkgs/i18n/internal"
 	"gopkg.in/ini.v1"
+	"gopkg.in/yaml.v3"
 )
 
 // getCallArgString 从方法调用中提取字符串入参
@@ -141,6 +145,64 @@ func extractBundleName(callExpr *ast.CallExpr, i18nAliases map[string]bool) stri
 	return ""
 }
 
+// extractBundleParents 从 i18n.Bundle("name", i18n.Extends("common")) 调用中提取父bundle名称
+func extractBundleParents(callExpr *ast.CallExpr, i18nAliases map[string]bool) []string {
+	parents := []string{}
+
+	if len(callExpr.Args) < 2 {
+		return parents
+	}
+
+	for _, arg := range callExpr.Args[1:] {
+		extendsCall, isCall := arg.(*ast.CallExpr)
+		if !isCall {
+			continue
+		}
+		selector, isSelector := extendsCall.Fun.(*ast.SelectorExpr)
+		if !isSelector || selector.Sel.Name != "Extends" {
+			continue
+		}
+		if selIdent, isIdent := selector.X.(*ast.Ident); !isIdent || !i18nAliases[selIdent.Name] {
+			continue
+		}
+		for i := range extendsCall.Args {
+			if parent := getCallArgString(extendsCall, i); parent != "" {
+				parents = append(parents, parent)
+			}
+		}
+	}
+
+	return parents
+}
+
+// readTranslationKeys 读取翻译文件中的键，文件不存在或解析失败时返回空集合
+func readTranslationKeys(filePath, fileType string) map[string]any {
+	keyValues := map[string]any{}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return keyValues
+	}
+
+	var unmarshal func(data []byte, v any) error
+	switch fileType {
+	case "yaml", "yml":
+		unmarshal = yaml.Unmarshal
+	case "toml", "tml":
+		unmarshal = toml.Unmarshal
+	case "ini":
+		unmarshal = i18nInternal.UnmarshalINI
+	default:
+		unmarshal = json.Unmarshal
+	}
+
+	if err := unmarshal(content, &keyValues); err != nil {
+		return map[string]any{}
+	}
+
+	return keyValues
+}
+
 func marshalINI(v any) ([]byte, error) {
 	// 创建一个新的INI文件对象
 	cfg := ini.Empty()
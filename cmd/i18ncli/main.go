@@ -24,6 +24,8 @@ from the source code.`,
 	}
 
 	rootCmd.AddCommand(extractCmd())
+	rootCmd.AddCommand(compileCmd())
+	rootCmd.AddCommand(genCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
@@ -42,8 +44,11 @@ func extractCmd() *cobra.Command {
 			langs, _ := cmd.Flags().GetStringSlice("lang")
 			output, _ := cmd.Flags().GetString("output")
 			fileType, _ := cmd.Flags().GetString("file-type")
+			layout, _ := cmd.Flags().GetString("layout")
+			embed, _ := cmd.Flags().GetBool("embed")
 
 			g := internal.NewGenerator(searchPath)
+			g.Layout = layout
 
 			if err := g.Walk(); err != nil {
 				fmt.Printf("Error: %v\n", err)
@@ -55,6 +60,13 @@ func extractCmd() *cobra.Command {
 				os.Exit(1)
 			}
 
+			if embed {
+				if err := g.GenerateEmbedFile(output); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
 			fmt.Println("Translation files generated successfully")
 
 			return nil
@@ -65,6 +77,82 @@ func extractCmd() *cobra.Command {
 	cmd.Flags().StringSliceP("lang", "l", []string{}, "Languages to generate translations for")
 	cmd.Flags().StringP("output", "o", "locales", "Output directory for generated translation files")
 	cmd.Flags().StringP("file-type", "f", "json", "File type for generated translation files")
+	cmd.Flags().String("layout", "", `Path pattern of the translation files relative to the output directory, with {lang} and {bundle} placeholders, e.g. "{bundle}/{lang}.yaml" (default "`+internal.DefaultLayout+`")`)
+	cmd.Flags().Bool("embed", false, "Generate the "+internal.EmbedFileName+" file embedding the translation files into the output directory package")
+
+	return cmd
+}
+
+func compileCmd() *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "compile",
+		Short: "Compile translation files into a Go package registering them at import time",
+		Long: `Compile translation files into a Go package holding the messages as map literals,
+which adds them to the default instance with i18n.AddCompiled when imported,
+so the locales directory is neither looked up nor read at startup.
+
+Template messages are only checked for syntax errors: they are still parsed at runtime,
+once, on first use. Plural forms are selected at runtime as with locale files.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			searchPath, _ := cmd.Flags().GetString("path")
+			input, _ := cmd.Flags().GetString("input")
+			output, _ := cmd.Flags().GetString("output")
+			layout, _ := cmd.Flags().GetString("layout")
+
+			g := internal.NewGenerator(searchPath)
+			g.Layout = layout
+
+			if err := g.CompileTranslationFiles(input, output); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Println("Translation files compiled successfully")
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("path", "p", ".", "Base path of the input and output directories")
+	cmd.Flags().StringP("input", "i", "locales", "Directory of the translation files")
+	cmd.Flags().StringP("output", "o", "catalog", "Output directory of the generated Go package, outside of the input directory")
+	cmd.Flags().String("layout", "", `Path pattern of the translation files relative to the input directory, with {lang} and {bundle} placeholders (default "`+internal.DefaultLayout+`")`)
+
+	return cmd
+}
+
+func genCmd() *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "gen",
+		Short: "Generate type-safe accessor functions for the messages of the translation files",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			searchPath, _ := cmd.Flags().GetString("path")
+			input, _ := cmd.Flags().GetString("input")
+			output, _ := cmd.Flags().GetString("output")
+			layout, _ := cmd.Flags().GetString("layout")
+
+			g := internal.NewGenerator(searchPath)
+			g.Layout = layout
+
+			if err := g.GenerateAccessors(input, output); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Println("Accessors generated successfully")
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("path", "p", ".", "Base path of the input and output directories")
+	cmd.Flags().StringP("input", "i", "locales", "Directory of the translation files")
+	cmd.Flags().StringP("output", "o", "msgs", "Output directory of the generated Go package, with a file per bundle")
+	cmd.Flags().String("layout", "", `Path pattern of the translation files relative to the input directory, with {lang} and {bundle} placeholders (default "`+internal.DefaultLayout+`")`)
 
 	return cmd
 }
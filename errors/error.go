@@ -8,6 +8,7 @@ import (
 
 	"github.com/epkgs/i18n/types"
 	pkgErrors "github.com/pkg/errors"
+	"golang.org/x/text/language"
 )
 
 var (
@@ -115,6 +116,19 @@ func (e *i18nError) T(ctx context.Context) string {
 	return e.msg.String()
 }
 
+// Language returns the language T translates the error message in based on context language preferences,
+// or language.Und if the message does not support translation
+func (e *i18nError) Language(ctx context.Context) language.Tag {
+	type matcher interface {
+		Language(ctx context.Context) language.Tag
+	}
+
+	if m, ok := e.msg.(matcher); ok {
+		return m.Language(ctx)
+	}
+	return language.Und
+}
+
 func (e *i18nError) TL(langs ...string) string {
 	type translator interface {
 		TL(langs ...string) string
@@ -252,3 +266,12 @@ func (e *i18nError) Has(key string) bool {
 	_, ok := e.extra[key]
 	return ok
 }
+
+// Extras returns a copy of the error's extra data
+func (e *i18nError) Extras() map[string]any {
+	extra := make(map[string]any, len(e.extra))
+	for k, v := range e.extra {
+		extra[k] = v
+	}
+	return extra
+}
@@ -23,22 +23,22 @@ func WithCode[E types.Storager](err E, code int) E {
 
 // Code retrieves the error code from an error
 //   - If the error is nil, returns the default code
-//   - If the error implements Storager and has a code set, returns that code
-//   - If the error has a Code() method, returns the result of that method
+//   - Otherwise, returns the code of the first error of the chain which either implements Storager
+//     and has a code set, or has a Code() method
 //
 // Otherwise, returns the default code
 func Code(err error) int {
-	if err == nil {
-		return CodeDefault
-	}
-
-	if storage, ok := err.(types.Storager); ok {
-
-		if code, ok := storage.Get("code", CodeDefault).(int); ok {
-			return code
+	for e := err; e != nil; e = Unwrap(e) {
+		if storage, ok := e.(types.Storager); ok && storage.Has("code") {
+			if code, ok := storage.Get("code", CodeDefault).(int); ok {
+				return code
+			}
+			return CodeDefault
 		}
 
-		return CodeDefault
+		if coder, ok := e.(interface{ Code() int }); ok {
+			return coder.Code()
+		}
 	}
 
 	var coder interface{ Code() int }
@@ -59,20 +59,22 @@ func WithHttpStatus[E types.Storager](err E, httpStatus int) E {
 
 // HttpStatus retrieves the HTTP status code from an error
 //   - If the error is nil, returns the default HTTP status
-//   - If the error implements Storager and has an HTTP status set, returns that status
-//   - If the error has an HttpStatus() method, returns the result of that method
+//   - Otherwise, returns the HTTP status of the first error of the chain which either implements Storager
+//     and has an HTTP status set, or has an HttpStatus() method
 //
 // Otherwise, returns the default HTTP status
 func HttpStatus(err error) int {
-	if err == nil {
-		return HttpStatusDefault
-	}
+	for e := err; e != nil; e = Unwrap(e) {
+		if storage, ok := e.(types.Storager); ok && storage.Has("http_status") {
+			if status, ok := storage.Get("http_status", HttpStatusDefault).(int); ok {
+				return status
+			}
+			return HttpStatusDefault
+		}
 
-	if storage, ok := err.(types.Storager); ok {
-		if status, ok := storage.Get("http_status", HttpStatusDefault).(int); ok {
-			return status
+		if e, ok := e.(interface{ HttpStatus() int }); ok {
+			return e.HttpStatus()
 		}
-		return HttpStatusDefault
 	}
 
 	var httpStatus interface{ HttpStatus() int }
@@ -0,0 +1,50 @@
+package errors
+
+import (
+	"github.com/epkgs/i18n/types"
+)
+
+// Keys of the extra data used by the problem details documents (RFC 9457)
+const (
+	ExtraProblemType = "type"
+	ExtraTitle       = "title"
+	ExtraInstance    = "instance"
+)
+
+// ExtraFields is the key of the messages per field of the validation errors in their extra data, e.g. those of i18nvalidator
+const ExtraFields = "fields"
+
+// WithProblemType sets the URI identifying the problem type of the error, e.g. "https://example.com/probs/out-of-credit"
+//   - E must implement the Storager interface to store the type
+//
+// Returns the same error with the type set
+func WithProblemType[E types.Storager](err E, uri string) E {
+	err.Set(ExtraProblemType, uri)
+	return err
+}
+
+// WithTitle sets the short summary of the problem type of the error
+//   - E must implement the Storager interface to store the title
+//   - title can be a string or a translatable types.Stringer, e.g. created with Bundle.Str
+//
+// Returns the same error with the title set
+func WithTitle[E types.Storager](err E, title any) E {
+	err.Set(ExtraTitle, title)
+	return err
+}
+
+// Extras returns a copy of the extra data of an error
+//   - If the error is nil, or it has no Extras() method, returns nil
+//
+// The extra data is set with Set, WithCode, WithHttpStatus...
+func Extras(err error) map[string]any {
+	if err == nil {
+		return nil
+	}
+
+	if e, ok := err.(interface{ Extras() map[string]any }); ok {
+		return e.Extras()
+	}
+
+	return nil
+}
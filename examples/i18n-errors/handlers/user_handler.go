@@ -1,15 +1,19 @@
 package handlers
 
 import (
+	"net/http"
+
 	"github.com/epkgs/i18n"
-	"github.com/epkgs/i18n/examples/i18n-errors/response"
+	"github.com/epkgs/i18n/errors"
+	"github.com/epkgs/i18n/i18ngin"
+	"github.com/epkgs/i18n/i18nvalidator"
 
 	"github.com/gin-gonic/gin"
 )
 
 type LoginRequest struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required,min=6"`
 }
 
 var bundle = i18n.Bundle("user")
@@ -17,9 +21,12 @@ var bundle = i18n.Bundle("user")
 func Login(c *gin.Context) {
 
 	var req LoginRequest
-	c.ShouldBindJSON(&req)
+	if err := c.ShouldBindJSON(&req); err != nil {
+		i18ngin.RenderError(c, i18nvalidator.Translate(err, &req))
+		return
+	}
 
-	err := bundle.Err("User %s not exist", req.Username)
+	err := errors.WithHttpStatus(bundle.Err("User %s not exist", req.Username), http.StatusNotFound)
 
-	response.Fail(c, err)
+	i18ngin.RenderError(c, err)
 }
@@ -1,16 +1,20 @@
 package main
 
 import (
-	"github.com/epkgs/i18n"
 	"github.com/epkgs/i18n/examples/i18n-errors/handlers"
+	"github.com/epkgs/i18n/i18ngin"
+	"github.com/epkgs/i18n/i18nvalidator"
 	"github.com/gin-gonic/gin"
 	"golang.org/x/text/language"
 )
 
 func main() {
+	// translations of the validation errors
+	i18nvalidator.RegisterCatalog("en", "zh-CN")
+
 	r := gin.Default()
 
-	r.Use(i18n.GinMiddleware(language.AmericanEnglish.String()))
+	r.Use(i18ngin.Middleware(language.AmericanEnglish.String()))
 
 	r.POST("/api/v1/user/login", handlers.Login)
 
@@ -2,9 +2,11 @@ package i18n
 
 import (
 	"encoding/json"
-	"os"
+	"io/fs"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/BurntSushi/toml"
 	"github.com/epkgs/i18n/internal"
@@ -19,16 +21,40 @@ type I18n struct {
 	defaultLanguage language.Tag
 	limitLanguages  []language.Tag
 
-	loader  internal.Loader
-	bundles map[string]types.Bundler
+	matcher   *internal.Matcher
+	layout    *internal.Layout
+	loader    internal.Loader
+	files     []string // locale files found by NewFS
+	sourcesMu sync.RWMutex
+	sources   []Source
+	mu        sync.RWMutex
+	bundles   map[string]types.Bundler
+	templates []*TemplateSet
+	setupErr  error       // error of the lookup of the locales of the default instance, see DefaultError
+	compiled  atomic.Bool // whether a compiled catalog was added, see AddCompiled
 }
 
 type Config struct {
 	DefaultLanguage string
 	Languages       []string
+
+	// PseudoLocales enables the pseudo locales en-XA (accented and expanded text)
+	// and ar-XB (right-to-left text), generated from the text of the default language.
+	PseudoLocales bool
+
+	// Layout is the path pattern of the locale files, relative to the locales directory,
+	// with the placeholders {lang} and {bundle} and the glob wildcards `*`, `?` and `**`,
+	// e.g. "{bundle}/{lang}.yaml" or "**/{bundle}/{lang}.json".
+	// When empty, the language and bundle are inferred from either `lang/bundle.ext` or `bundle.lang.ext`.
+	Layout string
+
+	// OverrideDir is a directory of locale files, organized like the main ones, which override
+	// the translations key by key, e.g. to fix translations on disk without rebuilding a binary
+	// with embedded locales. It is read again on Reload and ignored when it does not exist.
+	OverrideDir string
 }
 
-func newI18n(config ...func(c *Config)) *I18n {
+func newConfig(config ...func(c *Config)) *Config {
 	cfg := &Config{
 		DefaultLanguage: "en",
 		Languages:       []string{},
@@ -38,6 +64,12 @@ func newI18n(config ...func(c *Config)) *I18n {
 		f(cfg)
 	}
 
+	return cfg
+}
+
+func newI18n(config ...func(c *Config)) (*I18n, error) {
+	cfg := newConfig(config...)
+
 	n := &I18n{
 		cfg:             cfg,
 		defaultLanguage: internal.ParseLanguageTag(cfg.DefaultLanguage),
@@ -45,16 +77,33 @@ func newI18n(config ...func(c *Config)) *I18n {
 		bundles:         map[string]types.Bundler{},
 	}
 
-	return n
+	n.matcher = internal.NewMatcher(n.defaultLanguage, n.limitLanguages...)
+	n.matcher.SetPseudo(cfg.PseudoLocales)
+	n.matcher.Add(moduleLanguages()...)
+
+	if cfg.Layout != "" {
+		layout, err := internal.CompileLayout(cfg.Layout)
+		if err != nil {
+			return nil, err
+		}
+		n.layout = layout
+	}
+
+	return n, nil
 }
 
 func (n *I18n) SetDefault(langCode string) bool {
 
-	t, err := language.Parse(langCode)
+	t, err := internal.NormalizeLanguage(langCode)
 	if err != nil {
 		return false
 	}
 
+	n.matcher.SetDefaultLanguage(t)
+
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
 	for _, b := range n.bundles {
 		if ok := b.SetDefaultLanguage(t); !ok {
 			return false
@@ -64,24 +113,105 @@ func (n *I18n) SetDefault(langCode string) bool {
 	return true
 }
 
-func (n *I18n) Bundle(name string) types.Bundler {
+// BundleConfig is the configuration of a bundle
+type BundleConfig struct {
+	// Parents are the names of the bundles in which the keys missing in the bundle are resolved,
+	// in order, before falling back to the default language.
+	Parents []string
+}
 
-	if b, ok := n.bundles[name]; ok {
+// Extends makes the bundle inherit the translations of the parent bundles,
+// e.g. `i18n.Bundle("admin", i18n.Extends("common"))`.
+func Extends(parents ...string) func(c *BundleConfig) {
+	return func(c *BundleConfig) {
+		c.Parents = append(c.Parents, parents...)
+	}
+}
+
+// Bundle returns the named bundle, creating it on first use.
+// When config is given, it is applied to the bundle even if it already exists.
+func (n *I18n) Bundle(name string, config ...func(c *BundleConfig)) types.Bundler {
+
+	b := n.bundle(name)
+
+	if len(config) > 0 {
+		cfg := &BundleConfig{}
+		for _, f := range config {
+			f(cfg)
+		}
+
+		parents := make([]types.Bundler, len(cfg.Parents))
+		for i, parent := range cfg.Parents {
+			parents[i] = n.bundle(parent)
+		}
+
+		internal.SetParents(b, parents...)
+	}
+
+	return b
+}
+
+func (n *I18n) bundle(name string) types.Bundler {
+
+	n.mu.RLock()
+	b, ok := n.bundles[name]
+	n.mu.RUnlock()
+	if ok {
 		return b
 	}
 
-	matcher := internal.NewMatcher(n.defaultLanguage, n.limitLanguages...)
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if b, ok := n.bundles[name]; ok {
+		return b
+	}
 
-	b := internal.NewBundle(name, matcher, n.loader)
+	// the bundle shares the languages of the instance, but has its own default language
+	b = internal.NewBundle(name, n.matcher.Derive(), n.load)
 
 	n.bundles[name] = b
 	return b
 }
 
+// hasDefined reports whether messages were defined in code in the bundles of the instance, see Bundler.Define
+func (n *I18n) hasDefined() bool {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	for _, b := range n.bundles {
+		if internal.HasDefined(b) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Bundles returns the bundles created so far, by name
+func (n *I18n) Bundles() map[string]types.Bundler {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	bundles := make(map[string]types.Bundler, len(n.bundles))
+	for name, b := range n.bundles {
+		bundles[name] = b
+	}
+
+	return bundles
+}
+
 func (n *I18n) Reload() {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
 	for _, b := range n.bundles {
 		b.Reload()
 	}
+
+	for _, s := range n.templates {
+		s.Reload()
+	}
 }
 
 func (n *I18n) generateLoader(filePaths []string, readFile func(file string) ([]byte, error)) internal.Loader {
@@ -93,33 +223,16 @@ func (n *I18n) generateLoader(filePaths []string, readFile func(file string) ([]
 
 		for _, fpath := range filePaths {
 
-			if info, err := os.Stat(fpath); err != nil {
-				continue
-			} else {
-				if info.IsDir() {
-					continue
-				}
-			}
-
-			dir, filename := filepath.Split(fpath)
-			ext := filepath.Ext(filename)
-			filebase := filename[:len(filename)-len(ext)]
-
-			var lang string // language name
-			var name string // bundle name
-			if idx := strings.LastIndexByte(filebase, '.'); idx > 1 {
-				lang = filebase[idx+1:]
-				name = filebase[:idx]
-			} else {
-				lang = filepath.Base(dir)
-				name = filebase
+			lang, name, ext, ok := n.parseLocalePath(fpath)
+			if !ok {
+				continue // skip if the path does not match the layout
 			}
 
 			if bundleName != name {
 				continue // skip if bundle name does not match
 			}
 
-			tag, err := language.Parse(lang)
+			tag, err := internal.NormalizeLanguage(lang)
 			if err != nil {
 				continue
 			}
@@ -146,7 +259,7 @@ func (n *I18n) generateLoader(filePaths []string, readFile func(file string) ([]
 
 			data, err := readFile(fpath)
 			if err != nil {
-				continue
+				continue // skip directories and unreadable files
 			}
 
 			keyValues := make(map[string]any)
@@ -169,3 +282,86 @@ func (n *I18n) generateLoader(filePaths []string, readFile func(file string) ([]
 	}
 
 }
+
+// overrideLoader returns a loader which overrides the translations of the base loader
+// with those of the locale files of the file system, looked up on each load.
+func (n *I18n) overrideLoader(base internal.Loader, fileSystem fs.FS) internal.Loader {
+	if assets, err := internal.Glob(fileSystem, localePattern(n.cfg.Layout)); err == nil {
+		n.addLanguages(assets)
+	}
+
+	return func(bundleName string, m *internal.Matcher) map[language.Tag]map[string]string {
+		trans := base(bundleName, m)
+		if trans == nil {
+			trans = map[language.Tag]map[string]string{}
+		}
+
+		assets, err := internal.Glob(fileSystem, localePattern(n.cfg.Layout))
+		if err != nil {
+			return trans
+		}
+
+		overrides := n.generateLoader(assets, func(file string) ([]byte, error) {
+			return fs.ReadFile(fileSystem, file)
+		})(bundleName, m)
+
+		for lang, kv := range overrides {
+			if trans[lang] == nil {
+				trans[lang] = map[string]string{}
+			}
+			for key, value := range kv {
+				trans[lang][key] = value
+			}
+		}
+
+		return trans
+	}
+}
+
+// addLanguages registers the languages found in the locale file paths with the matcher,
+// so they are known before any bundle is loaded.
+func (n *I18n) addLanguages(filePaths []string) {
+	for _, tag := range n.localeLanguages(filePaths) {
+		n.matcher.MatchOrAdd(tag)
+	}
+}
+
+// localeLanguages returns the languages found in the locale file paths
+func (n *I18n) localeLanguages(filePaths []string) []language.Tag {
+	tags := []language.Tag{}
+
+	for _, fpath := range filePaths {
+		lang, _, _, ok := n.parseLocalePath(fpath)
+		if !ok {
+			continue
+		}
+
+		tag, err := internal.NormalizeLanguage(lang)
+		if err != nil || internal.Includes(tags, tag) {
+			continue
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags
+}
+
+// parseLocalePath infers the language, bundle name and extension of a locale file from the layout,
+// or when no layout is configured, either from `lang/bundle.ext` or from `bundle.lang.ext`.
+func (n *I18n) parseLocalePath(fpath string) (lang, name, ext string, ok bool) {
+	if n.layout != nil {
+		lang, name, ok = n.layout.Match(filepath.ToSlash(fpath))
+		return lang, name, filepath.Ext(fpath), ok
+	}
+
+	dir, filename := filepath.Split(fpath)
+	ext = filepath.Ext(filename)
+	filebase := filename[:len(filename)-len(ext)]
+
+	if idx := strings.LastIndexByte(filebase, '.'); idx > 1 {
+		return filebase[idx+1:], filebase[:idx], ext, true
+	}
+
+	return filepath.Base(dir), filebase, ext, true
+}
@@ -1,6 +1,14 @@
 package i18n
 
-import "github.com/epkgs/i18n/internal"
+import (
+	"github.com/epkgs/i18n/internal"
+	"github.com/epkgs/i18n/types"
+)
+
+// Text is a set of translations of a message by language code, used to declare messages in code, e.g.
+//
+//	var NotFound = bundle.Define("not_found", i18n.Text{"en": "Not found", "zh-CN": "未找到"})
+type Text = types.Text
 
 // WithAcceptLanguages returns a context with accepted languages.
 // This function is mainly used to add one or more accepted language codes to the context,
@@ -26,3 +34,29 @@ var WithAcceptLanguages = internal.WithAcceptLanguages
 // Returns:
 //   - []string: The list of accepted languages, or nil if not found.
 var GetAcceptLanguages = internal.GetAcceptLanguages
+
+// SetLanguageAlias maps an alias language to a target language, e.g. `zh-SG -> zh-Hans`.
+// The aliases are applied everywhere a language is parsed: locale file names, template directories,
+// the HTTP middlewares, `TL` and `NewLocalizer`.
+//
+// Parameters:
+//
+//   - alias string: The language to map, e.g. "zh-SG".
+//   - target string: The language it is mapped to, e.g. "zh-Hans".
+//
+// Returns:
+//   - error: An error if either language is invalid.
+var SetLanguageAlias = internal.SetLanguageAlias
+
+// NormalizeLanguage normalises a language string into a language tag.
+// Separators and case are canonicalised (`pt_br` -> `pt-BR`), deprecated codes are replaced
+// (`iw` -> `he`, `in` -> `id`) and the aliases configured by SetLanguageAlias are applied.
+//
+// Parameters:
+//
+//   - lang string: The language string, e.g. "zh_CN".
+//
+// Returns:
+//   - language.Tag: The normalised language tag.
+//   - error: An error if the language is invalid.
+var NormalizeLanguage = internal.NormalizeLanguage
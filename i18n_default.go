@@ -0,0 +1,242 @@
+package i18n
+
+import (
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/epkgs/i18n/internal"
+	"github.com/epkgs/i18n/types"
+	"golang.org/x/text/language"
+)
+
+// Environment variables configuring the default instance
+const (
+	// EnvDir is the locales directory of the default instance.
+	// When unset, `locales` is looked up in the working directory, then next to the executable.
+	EnvDir = "I18N_DIR"
+
+	// EnvDefaultLanguage is the default language of the default instance, "en" when unset.
+	EnvDefaultLanguage = "I18N_DEFAULT_LANGUAGE"
+)
+
+var (
+	defaultMu   sync.RWMutex
+	defaultI18n = newDefault()
+
+	errorHandlerMu sync.RWMutex
+	errorHandler   = func(err error) { log.Print(err) }
+)
+
+// Default returns the default instance used by the package-level functions.
+func Default() *I18n {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+
+	return defaultI18n
+}
+
+// SetDefault replaces the default instance used by the package-level functions.
+//
+// The bundles and template sets created from the previous default instance, e.g. package variables
+// initialized with `i18n.Bundle`, are moved to n, so they translate with the locales of n.
+// It should be called early, before serving any request.
+func SetDefault(n *I18n) {
+	if n == nil {
+		return
+	}
+
+	defaultMu.Lock()
+	old := defaultI18n
+	defaultI18n = n
+	defaultMu.Unlock()
+
+	if old != n {
+		n.adopt(old)
+	}
+}
+
+// newDefault creates the initial default instance.
+// Its locales directory is looked up on first use, not when the package is initialized,
+// so SetDefault and the environment variables can still be set by then.
+func newDefault() *I18n {
+	n, _ := newI18n(func(c *Config) {
+		if lang := os.Getenv(EnvDefaultLanguage); lang != "" {
+			c.DefaultLanguage = lang
+		}
+	})
+
+	var loader internal.Loader
+
+	n.matcher.Lazy(func() []language.Tag {
+		// a compiled catalog replaces the locales directory, unless it is set explicitly
+		if n.compiled.Load() && os.Getenv(EnvDir) == "" {
+			return nil
+		}
+
+		dir, err := findLocalesDir()
+		if err != nil {
+			// the translations may come from sources, modules or messages defined in code only, e.g. a compiled catalog
+			if !n.hasSources() && len(Modules()) == 0 && !n.hasDefined() {
+				n.setupFailed(err)
+			}
+			return nil
+		}
+
+		fileSystem := os.DirFS(dir)
+		assets, err := internal.Glob(fileSystem, "*/*")
+		if err != nil {
+			n.setupFailed(fmt.Errorf("i18n: look up the locale files in %s: %w", dir, err))
+			return nil
+		}
+		if len(assets) == 0 {
+			n.setupFailed(fmt.Errorf("i18n: no locale files found in %s", dir))
+			return nil
+		}
+
+		loader = n.generateLoader(assets, func(file string) ([]byte, error) {
+			return fs.ReadFile(fileSystem, file)
+		})
+
+		return n.localeLanguages(assets)
+	})
+
+	n.loader = func(bundleName string, m *internal.Matcher) map[language.Tag]map[string]string {
+		m.Prepare() // looks up the locales directory
+
+		if loader == nil {
+			return nil
+		}
+		return loader(bundleName, m)
+	}
+
+	return n
+}
+
+// setupFailed records the error of the lookup of the locales, see DefaultError, and reports it to the error handler
+func (n *I18n) setupFailed(err error) {
+	n.setupErr = err
+
+	errorHandlerMu.RLock()
+	handler := errorHandler
+	errorHandlerMu.RUnlock()
+
+	if handler != nil {
+		handler(err)
+	}
+}
+
+// SetErrorHandler sets the function reporting the error of the lookup of the locales directory
+// of the initial default instance, once on first use, see DefaultError.
+// The errors are logged with the standard logger by default, a nil handler discards them.
+// The handler must not use the default instance.
+//
+//	i18n.SetErrorHandler(func(err error) {
+//		slog.Warn("translations unavailable", "error", err)
+//	})
+func SetErrorHandler(handler func(err error)) {
+	errorHandlerMu.Lock()
+	defer errorHandlerMu.Unlock()
+
+	errorHandler = handler
+}
+
+// AddCompiled adds a catalog compiled by `i18ncli compile` to the default instance, see AddSource.
+// The initial default instance then no longer looks up its locales directory, unless I18N_DIR is set,
+// so no locale file is read at startup.
+func AddCompiled(src Source) {
+	n := Default()
+	n.compiled.Store(true)
+	n.AddSource(src)
+}
+
+// DefaultError returns the error of the lookup of the locales directory of the initial default instance,
+// e.g. when it is not found, or nil. The directory is looked up first if it was not yet.
+// The error is also reported on first use, see SetErrorHandler.
+//
+// Not finding the directory is not an error when the translations come from sources, modules
+// or messages defined in code. It returns nil once the default instance was replaced with SetDefault.
+//
+//	if err := i18n.DefaultError(); err != nil {
+//		log.Fatal(err)
+//	}
+func DefaultError() error {
+	n := Default()
+	n.matcher.Prepare()
+
+	return n.setupErr
+}
+
+// findLocalesDir returns the locales directory of the default instance
+func findLocalesDir() (string, error) {
+	if dir := os.Getenv(EnvDir); dir != "" {
+		if !isDir(dir) {
+			return "", fmt.Errorf("i18n: locales directory %s of %s not found", dir, EnvDir)
+		}
+		return dir, nil
+	}
+
+	candidates := []string{"locales"}
+	if exe, err := os.Executable(); err == nil {
+		if exe, err := filepath.EvalSymlinks(exe); err == nil {
+			candidates = append(candidates, filepath.Join(filepath.Dir(exe), "locales"))
+		}
+	}
+
+	for _, dir := range candidates {
+		if isDir(dir) {
+			return dir, nil
+		}
+	}
+
+	return "", fmt.Errorf("i18n: locales directory not found in %s, set %s or call i18n.SetDefault",
+		strings.Join(candidates, ", "), EnvDir)
+}
+
+func isDir(dir string) bool {
+	info, err := os.Stat(dir)
+	return err == nil && info.IsDir()
+}
+
+// adopt moves the bundles, template sets and sources of the previous instance to n.
+// The bundles of the previous instance replace those of n with the same name, as they are referenced by the callers,
+// and its sources get a lower priority than those of n.
+func (n *I18n) adopt(old *I18n) {
+	old.sourcesMu.Lock()
+	sources := old.sources
+	old.sources = nil
+	old.sourcesMu.Unlock()
+
+	for _, src := range sources {
+		n.matcher.Add(src.Languages()...)
+	}
+
+	n.sourcesMu.Lock()
+	n.sources = append(sources, n.sources...)
+	n.sourcesMu.Unlock()
+
+	old.mu.Lock()
+	bundles, templates := old.bundles, old.templates
+	old.bundles, old.templates = map[string]types.Bundler{}, nil
+	old.mu.Unlock()
+
+	for _, b := range bundles {
+		internal.Rebind(b, n.matcher.Derive(), n.load)
+	}
+
+	for _, s := range templates {
+		s.rebind(n)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for name, b := range bundles {
+		n.bundles[name] = b
+	}
+	n.templates = append(n.templates, templates...)
+}
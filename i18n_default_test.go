@@ -0,0 +1,97 @@
+package i18n
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDefaultSetupError(t *testing.T) {
+	t.Setenv(EnvDir, filepath.Join(t.TempDir(), "missing"))
+
+	// 找不到翻译目录时返回错误
+	n := newDefault()
+	n.matcher.Prepare()
+	if n.setupErr == nil {
+		t.Error("setup error = nil, want an error")
+	}
+
+	// 翻译全部定义在代码中时不是错误
+	n = newDefault()
+	n.Bundle("errors").Define("not_found", Text{"en": "Not found"})
+	n.matcher.Prepare()
+	if n.setupErr != nil {
+		t.Errorf("setup error = %v, want nil", n.setupErr)
+	}
+}
+
+func TestDefaultSetupErrorReported(t *testing.T) {
+	t.Setenv(EnvDir, filepath.Join(t.TempDir(), "missing"))
+
+	var reported []error
+	SetErrorHandler(func(err error) { reported = append(reported, err) })
+	t.Cleanup(func() { SetErrorHandler(func(err error) { log.Print(err) }) })
+
+	// 首次使用时报告一次错误
+	n := newDefault()
+	n.Bundle("user").Str("Hello").TL("en")
+	n.Bundle("admin").Str("Hello").TL("en")
+
+	if len(reported) != 1 || reported[0] != n.setupErr {
+		t.Errorf("reported = %v, want once %v", reported, n.setupErr)
+	}
+
+	// 处理函数为 nil 时不报告
+	SetErrorHandler(nil)
+	n = newDefault()
+	n.Bundle("user").Str("Hello").TL("en")
+	if len(reported) != 1 || n.setupErr == nil {
+		t.Errorf("reported = %v, setup error = %v, want no more report", reported, n.setupErr)
+	}
+}
+
+func TestDefaultCompiled(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "locales", "en"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "locales", "en", "user.json"), []byte(`{"Hello": "Hello from file"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+	t.Setenv(EnvDir, "")
+
+	// 注册编译的翻译后不再读取翻译目录
+	n := newDefault()
+	n.compiled.Store(true)
+	n.AddSource(Catalog{"user": {"en": {"Hello": "Hello from catalog"}}})
+
+	if got := n.Bundle("user").Str("Hello").TL("en"); got != "Hello from catalog" {
+		t.Errorf("TL(en) = %q, want %q", got, "Hello from catalog")
+	}
+	if n.setupErr != nil {
+		t.Errorf("setup error = %v, want nil", n.setupErr)
+	}
+}
+
+func TestDefineSetDefault(t *testing.T) {
+	old := Default()
+	t.Cleanup(func() { SetDefault(old) })
+
+	notFound := Bundle("define").Define("not_found", Text{"en": "Not found", "zh-CN": "未找到"})
+	if got := notFound.TL("zh-CN"); got != "未找到" {
+		t.Errorf("TL(zh-CN) = %q, want %q", got, "未找到")
+	}
+
+	n, err := NewKV(map[string]map[string]string{"en": {}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 替换默认实例后仍使用代码中定义的翻译
+	SetDefault(n)
+	if got := notFound.TL("zh-CN"); got != "未找到" {
+		t.Errorf("TL(zh-CN) after SetDefault = %q, want %q", got, "未找到")
+	}
+}
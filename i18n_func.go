@@ -1,53 +1,100 @@
 package i18n
 
 import (
+	"context"
+	"embed"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"text/template"
 
 	"github.com/epkgs/i18n/internal"
 	"github.com/epkgs/i18n/types"
 	"golang.org/x/text/language"
 )
 
-var defaultI18n *I18n
+// NewDir creates an I18n instance from the locale files under dir.
+// The files are organized as `dir/lang/bundle.ext`, or according to Config.Layout when set,
+// in which case dir is searched recursively.
+func NewDir(dir string, config ...func(c *Config)) (*I18n, error) {
+	return newDirFS(os.DirFS(dir), config...)
+}
 
-func init() {
-	defaultI18n, _ = NewDir("locales")
+// NewEmbed creates an I18n instance from the locale files embedded under root,
+// organized like the files of NewDir. Combined with SetDefault, it ships the locales inside the binary:
+//
+//	//go:embed locales
+//	var localesFS embed.FS
+//
+//	n, err := i18n.NewEmbed(localesFS, "locales")
+//	i18n.SetDefault(n)
+func NewEmbed(fsys embed.FS, root string, config ...func(c *Config)) (*I18n, error) {
+	sub, err := fs.Sub(fsys, root)
+	if err != nil {
+		return nil, err
+	}
+
+	return newDirFS(sub, config...)
 }
 
-func NewDir(dir string, config ...func(c *Config)) (*I18n, error) {
-	return NewGlob(filepath.Join(dir, "*/*"), config...)
+// newDirFS creates an I18n instance from the locale files of the file system
+func newDirFS(fileSystem fs.FS, config ...func(c *Config)) (*I18n, error) {
+	return NewFS(fileSystem, localePattern(newConfig(config...).Layout), config...)
 }
 
+// localePattern returns the glob pattern of the locale files of a directory
+func localePattern(layout string) string {
+	if layout != "" {
+		return "**"
+	}
+	return "*/*"
+}
+
+// NewGlob creates an I18n instance from the locale files matching the pattern,
+// which may use the `**` wildcard to match any number of directories.
 func NewGlob(pattern string, config ...func(c *Config)) (*I18n, error) {
 	return NewFS(os.DirFS("."), pattern, config...)
 }
 
+// NewFS creates an I18n instance from the locale files of the file system matching the pattern.
+// When Config.OverrideDir is set, the locale files under it override the translations key by key.
 func NewFS(fileSystem fs.FS, pattern string, config ...func(c *Config)) (*I18n, error) {
 
-	n := newI18n(config...)
+	n, err := newI18n(config...)
+	if err != nil {
+		return nil, err
+	}
 
-	assets, err := fs.Glob(fileSystem, pattern)
+	assets, err := internal.Glob(fileSystem, filepath.ToSlash(pattern))
 	if err != nil {
 		return nil, err
 	}
 
+	n.files = assets
+	n.addLanguages(assets)
+
 	n.loader = n.generateLoader(assets, func(file string) ([]byte, error) {
 		return fs.ReadFile(fileSystem, file)
 	})
 
+	if dir := n.cfg.OverrideDir; dir != "" {
+		n.loader = n.overrideLoader(n.loader, os.DirFS(dir))
+	}
+
 	return n, nil
 }
 
 func NewKV(langKeyValues map[string]map[string]string, config ...func(c *Config)) (*I18n, error) {
-	n := newI18n(config...)
+	n, err := newI18n(config...)
+	if err != nil {
+		return nil, err
+	}
 
-	languages := make([]language.Tag, len(langKeyValues))
-	i := 0
+	// the languages are kept as they are, so en-GB is not folded into en
 	for langCode := range langKeyValues {
-		languages[i] = internal.ParseLanguageTag(langCode)
-		i++
+		if tag := internal.ParseLanguageTag(langCode); tag != language.Und {
+			n.matcher.Insert(tag)
+		}
 	}
 
 	n.loader = func(bundleName string, m *internal.Matcher) map[language.Tag]map[string]string {
@@ -56,7 +103,7 @@ func NewKV(langKeyValues map[string]map[string]string, config ...func(c *Config)
 		trans := map[language.Tag]map[string]string{}
 		for lang, kv := range langKeyValues {
 
-			tag, err := language.Parse(lang)
+			tag, err := internal.NormalizeLanguage(lang)
 			if err != nil {
 				continue
 			}
@@ -74,16 +121,52 @@ func NewKV(langKeyValues map[string]map[string]string, config ...func(c *Config)
 }
 
 func SetDefaultLanguage(lang string) {
-	defaultI18n.SetDefault(lang)
+	Default().SetDefault(lang)
+}
+
+func Bundle(name string, config ...func(c *BundleConfig)) types.Bundler {
+	return Default().Bundle(name, config...)
+}
+
+// AddSource adds a source of translations to the default instance, see I18n.AddSource.
+func AddSource(src Source) {
+	Default().AddSource(src)
+}
+
+// Bundles returns the bundles of the default instance created so far, by name
+func Bundles() map[string]types.Bundler {
+	return Default().Bundles()
 }
 
-func Bundle(name string) types.Bundler {
-	return defaultI18n.Bundle(name)
+// Languages returns the metadata of the languages known to the default instance.
+func Languages() []LanguageInfo {
+	return Default().Languages()
+}
+
+// Negotiate returns the language known to the default instance which best matches the preferred languages, see I18n.Negotiate.
+func Negotiate(langs ...string) (tag language.Tag, index int, ok bool) {
+	return Default().Negotiate(langs...)
+}
+
+// TemplateDir returns a TemplateSet of the default instance for the template files under dir.
+func TemplateDir(dir string) *TemplateSet {
+	return Default().TemplateDir(dir)
 }
 
 // Reload reloads translation resources for all bundles in the cache.
 // It iterates through all bundle instances in the cache and calls their load method
 // to reload translation files from the filesystem.
 func Reload() {
-	defaultI18n.Reload()
+	Default().Reload()
+}
+
+// NewLocalizer returns a Localizer of the default instance for the given preferred languages.
+func NewLocalizer(langs ...string) *Localizer {
+	return Default().Localizer(langs...)
+}
+
+// FuncMap returns the template functions of the default instance bound to the
+// accepted languages stored in the context.
+func FuncMap(ctx context.Context) template.FuncMap {
+	return Default().FuncMap(ctx)
 }
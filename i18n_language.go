@@ -0,0 +1,70 @@
+package i18n
+
+import (
+	"github.com/epkgs/i18n/internal"
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+// LanguageInfo describes a language, mainly used to build language switchers
+// and the `dir` attribute of HTML documents.
+type LanguageInfo struct {
+	Tag        language.Tag `json:"-"`
+	Code       string       `json:"code"`        // BCP 47 language tag, e.g. "zh-CN"
+	Name       string       `json:"name"`        // English display name, e.g. "Chinese (China)"
+	NativeName string       `json:"native_name"` // display name in the language itself, e.g. "中文"
+	Script     string       `json:"script"`      // ISO 15924 script code, e.g. "Hans"
+	RTL        bool         `json:"rtl"`         // whether the language is written from right to left
+}
+
+// DescribeLanguage returns the metadata of the language tag.
+// When the display names of the language are unknown, the English name falls back to the code
+// and the native name to the English name, so a language switcher never shows an empty name.
+func DescribeLanguage(tag language.Tag) LanguageInfo {
+	script, _ := tag.Script()
+
+	info := LanguageInfo{
+		Tag:        tag,
+		Code:       tag.String(),
+		Name:       display.English.Tags().Name(tag),
+		NativeName: display.Self.Name(tag),
+		Script:     script.String(),
+		RTL:        internal.IsRTL(tag),
+	}
+
+	if info.Name == "" {
+		info.Name = info.Code
+	}
+	if info.NativeName == "" {
+		info.NativeName = info.Name
+	}
+
+	return info
+}
+
+// Languages returns the metadata of the languages known to the I18n instance,
+// the default language first.
+func (n *I18n) Languages() []LanguageInfo {
+	infos := []LanguageInfo{}
+
+	for _, tag := range n.matcher.Languages() {
+		if tag == language.Und {
+			continue
+		}
+		infos = append(infos, DescribeLanguage(tag))
+	}
+
+	return infos
+}
+
+// Negotiate returns the language known to the I18n instance which best matches the preferred languages,
+// trying them in order, along with the index of the preferred language it matches.
+// When none of them matches, it returns the default language, -1 and false.
+func (n *I18n) Negotiate(langs ...string) (tag language.Tag, index int, ok bool) {
+	return n.matcher.Negotiate(internal.ParseLanguageTags(langs...)...)
+}
+
+// LanguageInfo returns the metadata of the matched language of the Localizer
+func (l *Localizer) LanguageInfo() LanguageInfo {
+	return DescribeLanguage(l.tag)
+}
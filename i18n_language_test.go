@@ -0,0 +1,55 @@
+package i18n
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestDescribeLanguage(t *testing.T) {
+	tests := []struct {
+		lang string
+		want LanguageInfo
+	}{
+		{"zh-CN", LanguageInfo{Code: "zh-CN", Name: "Chinese (China)", NativeName: "中文", Script: "Hans"}},
+		{"zh-TW", LanguageInfo{Code: "zh-TW", Name: "Chinese (Taiwan)", NativeName: "繁體中文", Script: "Hant"}},
+		{"ja", LanguageInfo{Code: "ja", Name: "Japanese", NativeName: "日本語", Script: "Jpan"}},
+		// 从右到左书写的语言
+		{"ar", LanguageInfo{Code: "ar", Name: "Arabic", NativeName: "العربية", Script: "Arab", RTL: true}},
+		{"he", LanguageInfo{Code: "he", Name: "Hebrew", NativeName: "עברית", Script: "Hebr", RTL: true}},
+		{"ar-XB", LanguageInfo{Code: "ar-XB", Name: "Arabic", NativeName: "العربية", Script: "Arab", RTL: true}},
+		// 未知的显示名称回退到英文名称和代码
+		{"tlh", LanguageInfo{Code: "tlh", Name: "Klingon", NativeName: "Klingon", Script: "Zzzz"}},
+		{"qaa", LanguageInfo{Code: "qaa", Name: "qaa", NativeName: "qaa", Script: "Zzzz"}},
+	}
+
+	for _, tt := range tests {
+		tag := language.MustParse(tt.lang)
+		tt.want.Tag = tag
+
+		if got := DescribeLanguage(tag); got != tt.want {
+			t.Errorf("DescribeLanguage(%s) = %+v, want %+v", tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	n, err := NewKV(map[string]map[string]string{
+		"en": {},
+		"he": {},
+	}, func(c *Config) { c.DefaultLanguage = "he" })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 默认语言在前
+	infos := n.Languages()
+	if len(infos) != 2 || infos[0].Code != "he" || !infos[0].RTL || infos[1].Code != "en" || infos[1].RTL {
+		t.Errorf("Languages() = %+v, want he (rtl) then en", infos)
+	}
+
+	l := n.Localizer("iw")
+	if info := l.LanguageInfo(); info.Code != "he" || info.NativeName != "עברית" {
+		t.Errorf("LanguageInfo() = %+v, want he", info)
+	}
+}
@@ -0,0 +1,94 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"reflect"
+	"time"
+
+	"github.com/epkgs/i18n/internal"
+	"golang.org/x/text/language"
+)
+
+// Localizer translates and formats values for a fixed list of preferred languages.
+// It is mainly used to bind templates to the language of a request.
+type Localizer struct {
+	i18n  *I18n
+	langs []string
+	tag   language.Tag
+}
+
+// Localizer returns a Localizer for the given preferred languages.
+// The languages are matched against the languages known to the I18n instance,
+// falling back to the default language.
+func (n *I18n) Localizer(langs ...string) *Localizer {
+	return &Localizer{
+		i18n:  n,
+		langs: langs,
+		tag:   n.matcher.Match(internal.ParseLanguageTags(langs...)...),
+	}
+}
+
+// LocalizerCtx returns a Localizer for the accepted languages stored in the context.
+func (n *I18n) LocalizerCtx(ctx context.Context) *Localizer {
+	return n.Localizer(GetAcceptLanguages(ctx)...)
+}
+
+// Language returns the matched language of the Localizer
+func (l *Localizer) Language() language.Tag {
+	return l.tag
+}
+
+// Dir returns the text direction of the matched language, "rtl" or "ltr"
+func (l *Localizer) Dir() string {
+	if internal.IsRTL(l.tag) {
+		return "rtl"
+	}
+	return "ltr"
+}
+
+// T translates txt of the named bundle
+func (l *Localizer) T(bundle string, txt string, args ...any) string {
+	return l.i18n.Bundle(bundle).Str(txt, args...).TL(l.langs...)
+}
+
+// HTML translates the HTML text of the named bundle, see Bundler.HTML
+func (l *Localizer) HTML(bundle string, txt string, args ...any) template.HTML {
+	return l.i18n.Bundle(bundle).HTML(txt, args...).TL(l.langs...)
+}
+
+// N translates the singular or plural form of the named bundle based on n.
+// See Bundler.NStr for the accepted values of n.
+func (l *Localizer) N(bundle string, n any, one, others string, args ...any) string {
+	return l.i18n.Bundle(bundle).NStr(n, one, others, args...).TL(l.langs...)
+}
+
+// Number formats a numeric value with the digits and separators of the matched language
+func (l *Localizer) Number(v any) string {
+	return internal.FormatNumber(l.tag, v)
+}
+
+// Date formats t with the given layout, or with the default date layout of the matched language
+func (l *Localizer) Date(t time.Time, layout ...string) string {
+	var lay string
+	if len(layout) > 0 {
+		lay = layout[0]
+	}
+	return internal.FormatDate(l.tag, t, lay)
+}
+
+// List joins the items of a slice or array using the conjunction of the matched language
+func (l *Localizer) List(items any) string {
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return fmt.Sprint(items)
+	}
+
+	strs := make([]string, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		strs[i] = fmt.Sprint(v.Index(i).Interface())
+	}
+
+	return internal.FormatList(l.tag, strs)
+}
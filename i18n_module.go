@@ -0,0 +1,139 @@
+package i18n
+
+import (
+	"embed"
+	"sync"
+
+	"github.com/epkgs/i18n/internal"
+	"golang.org/x/text/language"
+)
+
+// ModuleInfo describes the translations registered by a Go module with RegisterModule
+type ModuleInfo struct {
+	Module  string   `json:"module"`  // module path, e.g. "github.com/acme/auth"
+	Bundles []string `json:"bundles"` // names of the bundles the module supplies translations for
+}
+
+type moduleSource struct {
+	ModuleInfo
+	src Source
+}
+
+var (
+	modulesMu sync.RWMutex
+	modules   []moduleSource
+)
+
+// RegisterModule registers the translations shipped by a Go module for its bundles, usually from an init function:
+//
+//	//go:embed locales
+//	var localesFS embed.FS
+//
+//	func init() {
+//		src, err := i18n.EmbedSource(localesFS, "locales")
+//		if err != nil {
+//			panic(err)
+//		}
+//		i18n.RegisterModule("github.com/acme/auth", src, "auth")
+//	}
+//
+// The translations of the modules are used by all instances, with a lower priority than their sources and locale files,
+// so applications override them key by key. When no bundle is given, the bundles reported by the source are used,
+// which is supported by Catalog and EmbedSource.
+func RegisterModule(module string, src Source, bundles ...string) {
+	if len(bundles) == 0 {
+		if b, ok := src.(interface{ Bundles() []string }); ok {
+			bundles = b.Bundles()
+		}
+	}
+
+	modulesMu.Lock()
+	modules = append(modules, moduleSource{
+		ModuleInfo: ModuleInfo{Module: module, Bundles: bundles},
+		src:        src,
+	})
+	modulesMu.Unlock()
+
+	// the default instance may already exist, the other ones are created later
+	n := Default()
+	n.matcher.Add(src.Languages()...)
+	n.Reload()
+}
+
+// Modules returns the modules registered with RegisterModule and the bundles they supply, in registration order.
+func Modules() []ModuleInfo {
+	modulesMu.RLock()
+	defer modulesMu.RUnlock()
+
+	infos := make([]ModuleInfo, len(modules))
+	for i, m := range modules {
+		infos[i] = ModuleInfo{Module: m.Module, Bundles: append([]string(nil), m.Bundles...)}
+	}
+
+	return infos
+}
+
+// moduleSources returns the sources of the modules supplying the bundle
+func moduleSources(bundle string) []Source {
+	modulesMu.RLock()
+	defer modulesMu.RUnlock()
+
+	sources := []Source{}
+	for _, m := range modules {
+		if internal.Includes(m.Bundles, bundle) {
+			sources = append(sources, m.src)
+		}
+	}
+
+	return sources
+}
+
+// moduleLanguages returns the languages of the registered modules
+func moduleLanguages() []language.Tag {
+	modulesMu.RLock()
+	defer modulesMu.RUnlock()
+
+	tags := []language.Tag{}
+	for _, m := range modules {
+		tags = append(tags, m.src.Languages()...)
+	}
+
+	return tags
+}
+
+// EmbedSource returns a Source of the locale files embedded under root, organized like the files of NewDir.
+func EmbedSource(fsys embed.FS, root string, config ...func(c *Config)) (Source, error) {
+	n, err := NewEmbed(fsys, root, config...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filesSource{n: n}, nil
+}
+
+// filesSource is a Source of the locale files of an instance
+type filesSource struct {
+	n *I18n
+}
+
+// Languages returns the languages of the locale files
+func (s *filesSource) Languages() []language.Tag {
+	return s.n.localeLanguages(s.n.files)
+}
+
+// Load returns the translations of the bundle
+func (s *filesSource) Load(bundle string) map[language.Tag]map[string]string {
+	return s.n.loader(bundle, s.n.matcher)
+}
+
+// Bundles returns the names of the bundles of the locale files
+func (s *filesSource) Bundles() []string {
+	bundles := []string{}
+	for _, fpath := range s.n.files {
+		if _, name, _, ok := s.n.parseLocalePath(fpath); ok && !internal.Includes(bundles, name) {
+			bundles = append(bundles, name)
+		}
+	}
+
+	return bundles
+}
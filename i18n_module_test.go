@@ -0,0 +1,94 @@
+package i18n
+
+import (
+	"embed"
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"golang.org/x/text/language"
+)
+
+//go:embed testdata
+var testdataFS embed.FS
+
+// resetModules restores the registered modules at the end of the test
+func resetModules(t *testing.T) {
+	t.Helper()
+
+	modulesMu.RLock()
+	registered := modules
+	modulesMu.RUnlock()
+
+	t.Cleanup(func() {
+		modulesMu.Lock()
+		modules = registered
+		modulesMu.Unlock()
+	})
+}
+
+func TestEmbedSource(t *testing.T) {
+	src, err := EmbedSource(testdataFS, "testdata/module")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 只加载根目录下的翻译文件
+	if got := src.Load("auth"); len(got) != 2 || got[language.MustParse("en")]["Sign in"] != "Sign in" || got[language.MustParse("zh-CN")]["Sign in"] != "登录" {
+		t.Errorf("Load(auth) = %v", got)
+	}
+	if got := src.Load("account"); got[language.MustParse("en")]["Profile"] != "Profile" {
+		t.Errorf("Load(account) = %v", got)
+	}
+
+	if got := src.(interface{ Bundles() []string }).Bundles(); !reflect.DeepEqual(got, []string{"account", "auth"}) {
+		t.Errorf("Bundles() = %v, want [account auth]", got)
+	}
+}
+
+func TestRegisterModule(t *testing.T) {
+	resetModules(t)
+
+	src, err := EmbedSource(testdataFS, "testdata/module")
+	if err != nil {
+		t.Fatal(err)
+	}
+	RegisterModule("example.com/auth", src)
+	RegisterModule("example.com/billing", Catalog{"billing": {"en": {"Pay": "Pay"}}})
+	RegisterModule("example.com/admin", Catalog{"admin": {"en": {}}, "audit": {"en": {}}}, "admin")
+
+	// 按注册顺序返回模块
+	want := []ModuleInfo{
+		{Module: "example.com/auth", Bundles: []string{"account", "auth"}},
+		{Module: "example.com/billing", Bundles: []string{"billing"}},
+		{Module: "example.com/admin", Bundles: []string{"admin"}},
+	}
+	if got := Modules(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Modules() = %v, want %v", got, want)
+	}
+
+	// 翻译文件按键覆盖模块的翻译
+	n, err := NewFS(fstest.MapFS{
+		"zh-CN/auth.json": {Data: []byte(`{"Sign in": "登录账号"}`)},
+	}, "*/*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := n.Bundle("auth")
+
+	tests := []struct {
+		key, lang, want string
+	}{
+		{"Sign in", "zh-CN", "登录账号"},
+		{"Sign out", "zh-CN", "退出"},
+		{"Sign in", "en", "Sign in"},
+	}
+	for _, tt := range tests {
+		if got := auth.Str(tt.key).TL(tt.lang); got != tt.want {
+			t.Errorf("%s in %s = %q, want %q", tt.key, tt.lang, got, tt.want)
+		}
+	}
+	if got := n.Bundle("billing").Str("Pay").TL("en"); got != "Pay" {
+		t.Errorf("Pay = %q, want %q", got, "Pay")
+	}
+}
@@ -0,0 +1,131 @@
+package i18n
+
+import (
+	"sort"
+
+	"github.com/epkgs/i18n/internal"
+	"golang.org/x/text/language"
+)
+
+// Source provides translations to an I18n instance besides its locale files,
+// e.g. a catalog compiled into Go code by `i18ncli compile`.
+type Source interface {
+	// Languages returns the languages the source has translations for
+	Languages() []language.Tag
+
+	// Load returns the translations of the bundle, by language and key
+	Load(bundle string) map[language.Tag]map[string]string
+}
+
+// Catalog is a Source of translations held in memory, by bundle name, language and key.
+type Catalog map[string]map[string]map[string]string
+
+// Languages returns the languages of the catalog
+func (c Catalog) Languages() []language.Tag {
+	tags := []language.Tag{}
+
+	for _, langs := range c {
+		for lang := range langs {
+			if tag := internal.ParseLanguageTag(lang); tag != language.Und && !internal.Includes(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	return tags
+}
+
+// Bundles returns the names of the bundles of the catalog
+func (c Catalog) Bundles() []string {
+	bundles := make([]string, 0, len(c))
+	for bundle := range c {
+		bundles = append(bundles, bundle)
+	}
+	sort.Strings(bundles)
+
+	return bundles
+}
+
+// Load returns the translations of the bundle
+func (c Catalog) Load(bundle string) map[language.Tag]map[string]string {
+	trans := map[language.Tag]map[string]string{}
+
+	for lang, kv := range c[bundle] {
+		tag := internal.ParseLanguageTag(lang)
+		if tag == language.Und {
+			continue
+		}
+
+		if trans[tag] == nil {
+			trans[tag] = make(map[string]string, len(kv))
+		}
+		for key, value := range kv {
+			trans[tag][key] = value
+		}
+	}
+
+	return trans
+}
+
+// AddSource adds a source of translations to the instance.
+// The locale files of the instance override the translations of its sources,
+// and the sources added later override those added earlier.
+func (n *I18n) AddSource(src Source) {
+	n.matcher.Add(src.Languages()...)
+
+	n.sourcesMu.Lock()
+	n.sources = append(n.sources, src)
+	n.sourcesMu.Unlock()
+
+	n.Reload()
+}
+
+// hasSources reports whether sources were added to the instance
+func (n *I18n) hasSources() bool {
+	n.sourcesMu.RLock()
+	defer n.sourcesMu.RUnlock()
+
+	return len(n.sources) > 0
+}
+
+// load loads the translations of the bundle from the registered modules, then from the sources of the instance,
+// then from its locale files
+func (n *I18n) load(bundleName string, m *internal.Matcher) map[language.Tag]map[string]string {
+	n.sourcesMu.RLock()
+	sources := n.sources
+	n.sourcesMu.RUnlock()
+
+	limit := n.limitLanguages
+	trans := map[language.Tag]map[string]string{}
+
+	merge := func(src map[language.Tag]map[string]string) {
+		for tag, kv := range src {
+			if len(limit) > 0 && !internal.Includes(limit, tag) {
+				continue
+			}
+
+			tag = m.MatchOrAdd(tag)
+
+			if trans[tag] == nil {
+				trans[tag] = make(map[string]string, len(kv))
+			}
+			for key, value := range kv {
+				trans[tag][key] = value
+			}
+		}
+	}
+
+	for _, src := range moduleSources(bundleName) {
+		merge(src.Load(bundleName))
+	}
+
+	for _, src := range sources {
+		merge(src.Load(bundleName))
+	}
+
+	if n.loader != nil {
+		merge(n.loader(bundleName, m))
+	}
+
+	return trans
+}
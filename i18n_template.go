@@ -0,0 +1,81 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	htmltemplate "html/template"
+	"text/template"
+
+	"github.com/epkgs/i18n/types"
+)
+
+// FuncMap returns the template functions of the Localizer.
+// The result can be used with both html/template and text/template:
+//
+//	{{ t "user" "User %s not exist" .Name }}    translate a text of a bundle
+//	{{ t .Err }}                                translate a types.Translator
+//	{{ th "user" "Hello <b>%s</b>" .Name }}     translate a sanitized HTML text, see Bundler.HTML
+//	{{ th .Msg }}                               translate a types.HTMLStringer
+//	{{ tn "user" .Count "%d item" "%d items" .Count }}
+//	{{ number .Total }}                         localized number
+//	{{ date .CreatedAt }}                       localized date, an optional layout may follow
+//	{{ list .Names }}                           localized list
+//	{{ lang }} {{ dir }}                        language tag and text direction
+//	{{ (langInfo).NativeName }}                 metadata of the language, see LanguageInfo
+func (l *Localizer) FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"t":        l.translate,
+		"th":       l.translateHTML,
+		"tn":       l.N,
+		"number":   l.Number,
+		"date":     l.Date,
+		"list":     l.List,
+		"lang":     func() string { return l.tag.String() },
+		"dir":      l.Dir,
+		"langInfo": l.LanguageInfo,
+	}
+}
+
+// translate implements the `t` template function
+func (l *Localizer) translate(v any, args ...any) (string, error) {
+	switch t := v.(type) {
+	case types.Translator:
+		return t.TL(l.langs...), nil
+	case string:
+		if len(args) == 0 {
+			return "", fmt.Errorf("t: missing text of bundle %q", t)
+		}
+		txt, ok := args[0].(string)
+		if !ok {
+			return "", fmt.Errorf("t: text of bundle %q must be a string, got %T", t, args[0])
+		}
+		return l.T(t, txt, args[1:]...), nil
+	default:
+		return "", fmt.Errorf("t: unsupported argument type %T", v)
+	}
+}
+
+// translateHTML implements the `th` template function
+func (l *Localizer) translateHTML(v any, args ...any) (htmltemplate.HTML, error) {
+	switch t := v.(type) {
+	case types.HTMLStringer:
+		return t.TL(l.langs...), nil
+	case string:
+		if len(args) == 0 {
+			return "", fmt.Errorf("th: missing text of bundle %q", t)
+		}
+		txt, ok := args[0].(string)
+		if !ok {
+			return "", fmt.Errorf("th: text of bundle %q must be a string, got %T", t, args[0])
+		}
+		return l.HTML(t, txt, args[1:]...), nil
+	default:
+		return "", fmt.Errorf("th: unsupported argument type %T", v)
+	}
+}
+
+// FuncMap returns the template functions bound to the accepted languages stored in the context.
+// See Localizer.FuncMap for the available functions.
+func (n *I18n) FuncMap(ctx context.Context) template.FuncMap {
+	return n.LocalizerCtx(ctx).FuncMap()
+}
@@ -0,0 +1,242 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sync"
+	"text/template"
+
+	"github.com/epkgs/i18n/internal"
+	"golang.org/x/text/language"
+)
+
+// TemplateSet is a set of per-language template files, organized as `root/{lang}/{name}`.
+//
+// The language of a template is resolved with the same matcher as the bundles of the I18n instance:
+// when the matched language has no template with the given name, the default language is used.
+// Parsed templates are cached per language and dropped by I18n.Reload.
+type TemplateSet struct {
+	i18n *I18n
+	fsys fs.FS
+	root string
+
+	mu       sync.Mutex
+	loadOnce *sync.Once
+	dirs     map[language.Tag]string // language -> directory under root
+	html     map[language.Tag]*htmltemplate.Template
+	text     map[language.Tag]*template.Template
+}
+
+// TemplateDir returns a TemplateSet for the template files under dir.
+func (n *I18n) TemplateDir(dir string) *TemplateSet {
+	return n.TemplateFS(os.DirFS(dir), ".")
+}
+
+// TemplateFS returns a TemplateSet for the template files under root of the file system.
+func (n *I18n) TemplateFS(fileSystem fs.FS, root string) *TemplateSet {
+	s := &TemplateSet{
+		i18n:     n,
+		fsys:     fileSystem,
+		root:     root,
+		loadOnce: &sync.Once{},
+	}
+
+	n.mu.Lock()
+	n.templates = append(n.templates, s)
+	n.mu.Unlock()
+
+	return s
+}
+
+// ExecuteHTML renders the named template with html/template in the language preferences of the context.
+func (s *TemplateSet) ExecuteHTML(ctx context.Context, w io.Writer, name string, data any) error {
+	for _, tag := range s.candidates(ctx) {
+		tmpl, err := s.htmlTemplate(tag)
+		if err != nil {
+			return err
+		}
+
+		if tmpl.Lookup(name) == nil {
+			continue
+		}
+
+		clone, err := tmpl.Clone()
+		if err != nil {
+			return err
+		}
+
+		return clone.Funcs(s.instance().FuncMap(ctx)).ExecuteTemplate(w, name, data)
+	}
+
+	return fmt.Errorf("i18n: template %q not found", name)
+}
+
+// ExecuteText renders the named template with text/template in the language preferences of the context.
+func (s *TemplateSet) ExecuteText(ctx context.Context, w io.Writer, name string, data any) error {
+	for _, tag := range s.candidates(ctx) {
+		tmpl, err := s.textTemplate(tag)
+		if err != nil {
+			return err
+		}
+
+		if tmpl.Lookup(name) == nil {
+			continue
+		}
+
+		clone, err := tmpl.Clone()
+		if err != nil {
+			return err
+		}
+
+		return clone.Funcs(s.instance().FuncMap(ctx)).ExecuteTemplate(w, name, data)
+	}
+
+	return fmt.Errorf("i18n: template %q not found", name)
+}
+
+// Reload drops the parsed templates, they are parsed again on next use
+func (s *TemplateSet) Reload() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.loadOnce = &sync.Once{}
+}
+
+// rebind moves the set to another instance, the templates are parsed again on next use
+func (s *TemplateSet) rebind(n *I18n) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.i18n = n
+	s.loadOnce = &sync.Once{}
+}
+
+// instance returns the I18n instance of the set, which is changed by rebind
+func (s *TemplateSet) instance() *I18n {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.i18n
+}
+
+// candidates returns the matched language of the context followed by the default language
+func (s *TemplateSet) candidates(ctx context.Context) []language.Tag {
+	s.lazyLoad()
+
+	tags := internal.ParseLanguageTags(GetAcceptLanguages(ctx)...)
+
+	n := s.instance()
+	matched := n.matcher.Match(tags...)
+	defaultLanguage := n.matcher.DefaultLanguage()
+
+	if matched == defaultLanguage {
+		return []language.Tag{matched}
+	}
+
+	return []language.Tag{matched, defaultLanguage}
+}
+
+func (s *TemplateSet) lazyLoad() {
+	s.mu.Lock()
+	once := s.loadOnce
+	s.mu.Unlock()
+
+	once.Do(func() {
+		n := s.instance()
+		dirs := map[language.Tag]string{}
+
+		entries, _ := fs.ReadDir(s.fsys, s.root)
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+
+			tag, err := internal.NormalizeLanguage(entry.Name())
+			if err != nil {
+				continue
+			}
+
+			if limit := n.limitLanguages; len(limit) > 0 && !internal.Includes(limit, tag) {
+				continue
+			}
+
+			dirs[n.matcher.MatchOrAdd(tag)] = entry.Name()
+		}
+
+		s.mu.Lock()
+		s.dirs = dirs
+		s.html = map[language.Tag]*htmltemplate.Template{}
+		s.text = map[language.Tag]*template.Template{}
+		s.mu.Unlock()
+	})
+}
+
+func (s *TemplateSet) htmlTemplate(tag language.Tag) (*htmltemplate.Template, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if tmpl, ok := s.html[tag]; ok {
+		return tmpl, nil
+	}
+
+	tmpl := htmltemplate.New("").Funcs(s.i18n.FuncMap(context.Background()))
+	err := s.walk(tag, func(name, content string) error {
+		_, err := tmpl.New(name).Parse(content)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.html[tag] = tmpl
+	return tmpl, nil
+}
+
+func (s *TemplateSet) textTemplate(tag language.Tag) (*template.Template, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if tmpl, ok := s.text[tag]; ok {
+		return tmpl, nil
+	}
+
+	tmpl := template.New("").Funcs(s.i18n.FuncMap(context.Background()))
+	err := s.walk(tag, func(name, content string) error {
+		_, err := tmpl.New(name).Parse(content)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.text[tag] = tmpl
+	return tmpl, nil
+}
+
+// walk calls parse for every template file of the language, named by its path relative to the language directory
+func (s *TemplateSet) walk(tag language.Tag, parse func(name, content string) error) error {
+	dir, ok := s.dirs[tag]
+	if !ok {
+		return nil
+	}
+
+	langDir := path.Join(s.root, dir)
+
+	return fs.WalkDir(s.fsys, langDir, func(fpath string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		data, err := fs.ReadFile(s.fsys, fpath)
+		if err != nil {
+			return err
+		}
+
+		return parse(fpath[len(langDir)+1:], string(data))
+	})
+}
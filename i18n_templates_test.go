@@ -0,0 +1,147 @@
+package i18n
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"testing/fstest"
+)
+
+func newTestTemplates(t *testing.T) (*I18n, fstest.MapFS) {
+	t.Helper()
+
+	n, err := NewKV(map[string]map[string]string{
+		"en":    {"Hello": "Hello"},
+		"zh-CN": {"Hello": "你好"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fsys := fstest.MapFS{
+		"templates/en/mail/welcome.txt":    {Data: []byte(`Welcome {{ .Name }}, {{ t "user" "Hello" }}`)},
+		"templates/en/footer.txt":          {Data: []byte(`Bye`)},
+		"templates/zh-CN/mail/welcome.txt": {Data: []byte(`欢迎 {{ .Name }}，{{ t "user" "Hello" }}`)},
+	}
+
+	return n, fsys
+}
+
+// execute renders the template of the set in the languages
+func execute(t *testing.T, s *TemplateSet, name string, langs ...string) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	ctx := WithAcceptLanguages(context.Background(), langs...)
+	if err := s.ExecuteText(ctx, &buf, name, map[string]string{"Name": "alice"}); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestTemplateSet(t *testing.T) {
+	n, fsys := newTestTemplates(t)
+	s := n.TemplateFS(fsys, "templates")
+
+	tests := []struct {
+		name  string
+		langs []string
+		want  string
+	}{
+		// 按请求的语言选择模板
+		{"mail/welcome.txt", []string{"zh-CN"}, "欢迎 alice，你好"},
+		{"mail/welcome.txt", []string{"fr", "zh_CN"}, "欢迎 alice，你好"},
+		{"mail/welcome.txt", []string{"en-US"}, "Welcome alice, Hello"},
+		// 不支持的语言使用默认语言
+		{"mail/welcome.txt", []string{"fr"}, "Welcome alice, Hello"},
+		// 该语言没有的模板使用默认语言的模板
+		{"footer.txt", []string{"zh-CN"}, "Bye"},
+	}
+
+	for _, tt := range tests {
+		if got := execute(t, s, tt.name, tt.langs...); got != tt.want {
+			t.Errorf("%s in %v = %q, want %q", tt.name, tt.langs, got, tt.want)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := s.ExecuteText(context.Background(), &buf, "missing.txt", nil); err == nil {
+		t.Error("missing template: err = nil, want an error")
+	}
+
+	// HTML 模板转义数据
+	buf.Reset()
+	ctx := WithAcceptLanguages(context.Background(), "zh-CN")
+	if err := s.ExecuteHTML(ctx, &buf, "mail/welcome.txt", map[string]string{"Name": "<b>"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "欢迎 &lt;b&gt;，你好" {
+		t.Errorf("ExecuteHTML = %q, want %q", got, "欢迎 &lt;b&gt;，你好")
+	}
+}
+
+func TestTemplateSetReload(t *testing.T) {
+	n, fsys := newTestTemplates(t)
+	s := n.TemplateFS(fsys, "templates")
+
+	if got := execute(t, s, "footer.txt", "en"); got != "Bye" {
+		t.Fatalf("footer.txt = %q, want %q", got, "Bye")
+	}
+
+	// 重新加载后使用修改的模板和新增的语言
+	fsys["templates/en/footer.txt"] = &fstest.MapFile{Data: []byte(`Goodbye`)}
+	fsys["templates/zh-CN/footer.txt"] = &fstest.MapFile{Data: []byte(`再见`)}
+
+	if got := execute(t, s, "footer.txt", "en"); got != "Bye" {
+		t.Errorf("footer.txt before Reload = %q, want cached %q", got, "Bye")
+	}
+
+	n.Reload()
+	if got := execute(t, s, "footer.txt", "en"); got != "Goodbye" {
+		t.Errorf("footer.txt after Reload = %q, want %q", got, "Goodbye")
+	}
+	if got := execute(t, s, "footer.txt", "zh-CN"); got != "再见" {
+		t.Errorf("footer.txt in zh-CN after Reload = %q, want %q", got, "再见")
+	}
+}
+
+func TestTemplateSetSetDefault(t *testing.T) {
+	old := Default()
+	t.Cleanup(func() { SetDefault(old) })
+
+	_, fsys := newTestTemplates(t)
+	s := Default().TemplateFS(fsys, "templates")
+
+	n, err := NewKV(map[string]map[string]string{
+		"en":    {"Hello": "Hi"},
+		"zh-CN": {"Hello": "您好"},
+	}, func(c *Config) { c.DefaultLanguage = "zh-CN" })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 与替换默认实例并发渲染
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				var buf bytes.Buffer
+				_ = s.ExecuteText(context.Background(), &buf, "mail/welcome.txt", nil)
+			}
+		}()
+	}
+
+	// 替换默认实例后使用新实例的语言和翻译
+	SetDefault(n)
+	wg.Wait()
+
+	if got := execute(t, s, "mail/welcome.txt", "fr"); got != "欢迎 alice，您好" {
+		t.Errorf("welcome in fr = %q, want %q", got, "欢迎 alice，您好")
+	}
+	if got := execute(t, s, "mail/welcome.txt", "en"); got != "Welcome alice, Hi" {
+		t.Errorf("welcome in en = %q, want %q", got, "Welcome alice, Hi")
+	}
+}
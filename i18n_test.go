@@ -0,0 +1,293 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/epkgs/i18n/internal"
+	"golang.org/x/text/language"
+)
+
+func TestBundleDefaultLanguage(t *testing.T) {
+	n, err := NewKV(map[string]map[string]string{
+		"en":    {"Hello": "Hello"},
+		"zh-CN": {"Hello": "你好"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user, admin := n.Bundle("user"), n.Bundle("admin")
+
+	// 只修改一个 bundle 的默认语言
+	user.SetDefaultLanguage(language.MustParse("zh-CN"))
+
+	if got := user.Str("Hello").TL("fr"); got != "你好" {
+		t.Errorf("user: TL(fr) = %q, want %q", got, "你好")
+	}
+	if got := admin.Str("Hello").TL("fr"); got != "Hello" {
+		t.Errorf("admin: TL(fr) = %q, want %q", got, "Hello")
+	}
+
+	// 实例的默认语言作用于所有 bundle
+	n.SetDefault("zh-CN")
+	if got := admin.Str("Hello").TL("fr"); got != "你好" {
+		t.Errorf("admin after SetDefault: TL(fr) = %q, want %q", got, "你好")
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	n, err := NewKV(map[string]map[string]string{
+		"en":    {},
+		"zh-CN": {},
+		"ja":    {},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		langs     []string
+		wantTag   string
+		wantIndex int
+		wantOK    bool
+	}{
+		// 按偏好顺序逐个匹配
+		{[]string{"fr", "ja", "zh-CN"}, "ja", 1, true},
+		{[]string{"invalid!", "zh_CN"}, "zh-CN", 1, true},
+		{[]string{"en-US"}, "en", 0, true},
+		{[]string{"fr", "de"}, "en", -1, false},
+		{nil, "en", -1, false},
+	}
+
+	for _, tt := range tests {
+		tag, index, ok := n.Negotiate(tt.langs...)
+		if tag.String() != tt.wantTag || index != tt.wantIndex || ok != tt.wantOK {
+			t.Errorf("Negotiate(%v) = %v, %d, %v, want %s, %d, %v", tt.langs, tag, index, ok, tt.wantTag, tt.wantIndex, tt.wantOK)
+		}
+	}
+}
+
+func TestPseudoLocaleFile(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en/user.json":    {Data: []byte(`{"Hello": "Hello", "Bye": "Bye"}`)},
+		"en-XA/user.json": {Data: []byte(`{"Hello": "[Ĥéļļö ~~]"}`)},
+	}
+
+	for _, pseudo := range []bool{false, true} {
+		n, err := NewFS(fsys, "*/*", func(c *Config) { c.PseudoLocales = pseudo })
+		if err != nil {
+			t.Fatal(err)
+		}
+		user := n.Bundle("user")
+
+		// 伪本地化的翻译文件不合并到 en
+		if got := user.Str("Hello").TL("en"); got != "Hello" {
+			t.Errorf("pseudo %v: TL(en) = %q, want %q", pseudo, got, "Hello")
+		}
+		// 已加载的伪本地化翻译不再转换
+		if got := user.Str("Hello").TL("en-XA"); got != "[Ĥéļļö ~~]" {
+			t.Errorf("pseudo %v: TL(en-XA) = %q, want %q", pseudo, got, "[Ĥéļļö ~~]")
+		}
+	}
+
+	// 缺少的翻译由默认语言的文本生成
+	n, err := NewFS(fsys, "*/*", func(c *Config) { c.PseudoLocales = true })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := n.Bundle("user").Str("Bye").TL("en-XA"), internal.Pseudo(internal.PseudoAccents, "Bye"); got != want {
+		t.Errorf("TL(en-XA) = %q, want %q", got, want)
+	}
+}
+
+func TestKVRegionalLanguage(t *testing.T) {
+	// 多次运行以覆盖 map 的不同遍历顺序
+	for i := 0; i < 20; i++ {
+		n, err := NewKV(map[string]map[string]string{
+			"en":    {"Color": "Color"},
+			"en-GB": {"Color": "Colour"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// 地区语言不合并到基础语言
+		color := n.Bundle("test").Str("Color")
+		if got := color.TL("en"); got != "Color" {
+			t.Fatalf("TL(en) = %q, want %q", got, "Color")
+		}
+		if got := color.TL("en-GB"); got != "Colour" {
+			t.Fatalf("TL(en-GB) = %q, want %q", got, "Colour")
+		}
+		if got := color.TL("en-US"); got != "Color" {
+			t.Fatalf("TL(en-US) = %q, want %q", got, "Color")
+		}
+	}
+}
+
+func TestLayout(t *testing.T) {
+	fsys := fstest.MapFS{
+		"user/en.yaml":         {Data: []byte("Hello: Hello\n")},
+		"user/zh-CN.yaml":      {Data: []byte("Hello: 你好\n")},
+		"admin/en.yaml":        {Data: []byte("Users: Users\n")},
+		"user/ja.json":         {Data: []byte(`{"Hello": "こんにちは"}`)},
+		"user/README.md":       {Data: []byte("# Translations\n")},
+		"fr/user.yaml":         {Data: []byte("Hello: Bonjour\n")},
+		"user/invalid!.yaml":   {Data: []byte("Hello: Invalid\n")},
+		"user/de.yaml.example": {Data: []byte("Hello: Hallo\n")},
+	}
+
+	n, err := newDirFS(fsys, func(c *Config) { c.Layout = "{bundle}/{lang}.yaml" })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hello := n.Bundle("user").Str("Hello")
+	if got := hello.TL("zh-CN"); got != "你好" {
+		t.Errorf("TL(zh-CN) = %q, want %q", got, "你好")
+	}
+	if got := n.Bundle("admin").Str("Users").TL("en"); got != "Users" {
+		t.Errorf("admin: TL(en) = %q, want %q", got, "Users")
+	}
+
+	// 不匹配布局的文件被忽略
+	for _, lang := range []string{"ja", "fr", "de"} {
+		if got := hello.TL(lang); got != "Hello" {
+			t.Errorf("TL(%s) = %q, want the default %q", lang, got, "Hello")
+		}
+	}
+	if got := n.Bundle("user").Languages(); len(got) != 2 {
+		t.Errorf("Languages() = %v, want [en zh-CN]", got)
+	}
+}
+
+func TestLayoutRecursive(t *testing.T) {
+	fsys := fstest.MapFS{
+		"user/en.json":                   {Data: []byte(`{"Hello": "Hello"}`)},
+		"modules/billing/user/ja.json":   {Data: []byte(`{"Hello": "こんにちは"}`)},
+		"modules/billing/pay/zh_CN.json": {Data: []byte(`{"Pay": "支付"}`)},
+		"modules/billing/pay/zh-CN.yaml": {Data: []byte("Pay: 付款\n")},
+	}
+
+	n, err := newDirFS(fsys, func(c *Config) { c.Layout = "**/{bundle}/{lang}.json" })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// ** 匹配任意层目录，语言代码被规范化
+	if got := n.Bundle("user").Str("Hello").TL("ja"); got != "こんにちは" {
+		t.Errorf("TL(ja) = %q, want %q", got, "こんにちは")
+	}
+	if got := n.Bundle("pay").Str("Pay").TL("zh-CN"); got != "支付" {
+		t.Errorf("TL(zh-CN) = %q, want %q", got, "支付")
+	}
+}
+
+func TestExtends(t *testing.T) {
+	n, err := NewFS(fstest.MapFS{
+		"en/base.json":      {Data: []byte(`{"Save": "Save", "Cancel": "Cancel", "Delete": "Delete", "Help": "Help"}`)},
+		"zh-CN/base.json":   {Data: []byte(`{"Save": "保存", "Cancel": "取消"}`)},
+		"en/common.json":    {Data: []byte(`{"Cancel": "Dismiss"}`)},
+		"zh-CN/common.json": {Data: []byte(`{"Delete": "删除"}`)},
+		"en/admin.json":     {Data: []byte(`{"Users": "Users"}`)},
+		"zh-CN/admin.json":  {Data: []byte(`{"Users": "用户", "Save": "保存更改"}`)},
+	}, "*/*")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n.Bundle("common", Extends("base"))
+	admin := n.Bundle("admin", Extends("common"))
+
+	tests := []struct {
+		key, lang, want string
+	}{
+		// 子 bundle 的翻译优先
+		{"Users", "zh-CN", "用户"},
+		{"Save", "zh-CN", "保存更改"},
+		// 多层继承，按层级查找
+		{"Delete", "zh-CN", "删除"},
+		{"Cancel", "zh-CN", "取消"},
+		{"Cancel", "en", "Dismiss"},
+		// 所有 bundle 都缺少该语言时回退到默认语言
+		{"Help", "zh-CN", "Help"},
+		{"Save", "fr", "Save"},
+		{"Missing", "zh-CN", "Missing"},
+	}
+
+	for _, tt := range tests {
+		if got := admin.Str(tt.key).TL(tt.lang); got != tt.want {
+			t.Errorf("%s in %s = %q, want %q", tt.key, tt.lang, got, tt.want)
+		}
+	}
+
+	// 继承关系不能成环
+	n.Bundle("base", Extends("admin"))
+	if got := admin.Str("Missing").TL("zh-CN"); got != "Missing" {
+		t.Errorf("Missing with a cycle = %q, want %q", got, "Missing")
+	}
+}
+
+func TestEmbedOverrideDir(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile("en/auth.json", `{"Sign in": "Log in"}`)
+
+	n, err := NewEmbed(testdataFS, "testdata/module", func(c *Config) { c.OverrideDir = dir })
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := n.Bundle("auth")
+
+	tests := []struct {
+		key, lang, want string
+	}{
+		// 覆盖目录只替换其中的键
+		{"Sign in", "en", "Log in"},
+		{"Sign out", "en", "Sign out"},
+		{"Sign in", "zh-CN", "登录"},
+	}
+	for _, tt := range tests {
+		if got := auth.Str(tt.key).TL(tt.lang); got != tt.want {
+			t.Errorf("%s in %s = %q, want %q", tt.key, tt.lang, got, tt.want)
+		}
+	}
+	if got := n.Bundle("account").Str("Profile").TL("en"); got != "Profile" {
+		t.Errorf("Profile = %q, want %q", got, "Profile")
+	}
+
+	// 重新加载时再次读取覆盖目录，可以新增语言
+	writeFile("zh-CN/auth.json", `{"Sign out": "登出"}`)
+	writeFile("ja/auth.json", `{"Sign in": "ログイン"}`)
+	n.Reload()
+
+	if got := auth.Str("Sign out").TL("zh-CN"); got != "登出" {
+		t.Errorf("Sign out in zh-CN after Reload = %q, want %q", got, "登出")
+	}
+	if got := auth.Str("Sign in").TL("zh-CN"); got != "登录" {
+		t.Errorf("Sign in in zh-CN after Reload = %q, want %q", got, "登录")
+	}
+	if got := auth.Str("Sign in").TL("ja"); got != "ログイン" {
+		t.Errorf("Sign in in ja after Reload = %q, want %q", got, "ログイン")
+	}
+
+	// 不存在的覆盖目录被忽略
+	n, err = NewEmbed(testdataFS, "testdata/module", func(c *Config) { c.OverrideDir = filepath.Join(dir, "missing") })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := n.Bundle("auth").Str("Sign in").TL("en"); got != "Sign in" {
+		t.Errorf("Sign in without override = %q, want %q", got, "Sign in")
+	}
+}
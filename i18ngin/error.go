@@ -0,0 +1,148 @@
+package i18ngin
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/epkgs/i18n/errors"
+	"github.com/epkgs/i18n/i18nhttp"
+	"github.com/epkgs/i18n/types"
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorResponse is the content of an error response, shaped into the JSON body by ErrorConfig.Envelope
+type ErrorResponse struct {
+	HttpStatus int            // HTTP status, see errors.HttpStatus
+	Code       int            // error code, see errors.Code
+	Message    string         // message translated in the language of the request
+	Extra      map[string]any // selected extra data of the error, translated, see ErrorConfig.Extra
+	Cause      string         // full error message including its causes, only in debug mode
+}
+
+// ErrorConfig is the configuration of an ErrorRenderer
+type ErrorConfig struct {
+	// Extra are the keys of the extra data of the errors included in the responses, see types.Storager.
+	// The translatable values are translated. Default: errors.ExtraFields, the messages per field of i18nvalidator
+	Extra []string
+
+	// Debug reports whether the causes of the errors are included in the responses, e.g. gin.IsDebugging.
+	// Default: never, as the causes may reveal internal details
+	Debug func() bool
+
+	// Envelope returns the JSON body of the response, default: {"code": 1, "message": "...", ...extra, "cause": "..."}
+	Envelope func(c *gin.Context, resp ErrorResponse) any
+}
+
+// ErrorRenderer renders errors as JSON responses in the language of the request
+type ErrorRenderer struct {
+	cfg *ErrorConfig
+}
+
+// NewErrorRenderer returns an ErrorRenderer configured with the config functions
+//
+//	renderer := i18ngin.NewErrorRenderer(func(c *i18ngin.ErrorConfig) {
+//		c.Envelope = func(c *gin.Context, resp i18ngin.ErrorResponse) any {
+//			return gin.H{"error": gin.H{"code": resp.Code, "message": resp.Message}}
+//		}
+//	})
+func NewErrorRenderer(config ...func(c *ErrorConfig)) *ErrorRenderer {
+	cfg := &ErrorConfig{
+		Extra:    []string{errors.ExtraFields},
+		Debug:    func() bool { return false },
+		Envelope: defaultEnvelope,
+	}
+	for _, fn := range config {
+		fn(cfg)
+	}
+
+	return &ErrorRenderer{cfg: cfg}
+}
+
+var defaultErrorRenderer = NewErrorRenderer()
+
+// RenderError renders the error with the default ErrorRenderer, see ErrorRenderer.Render
+//
+//	if err := svc.Login(ctx, req); err != nil {
+//		i18ngin.RenderError(c, err)
+//		return
+//	}
+func RenderError(c *gin.Context, err error) {
+	defaultErrorRenderer.Render(c, err)
+}
+
+// ErrorHandler is a middleware rendering the last error of the Gin context with the default ErrorRenderer,
+// when the handlers add an error with c.Error without writing a response, see ErrorRenderer.Handler
+func ErrorHandler() gin.HandlerFunc {
+	return defaultErrorRenderer.Handler()
+}
+
+// Render renders the error and aborts the Gin chain.
+//
+// The message is the one of the first translatable error in the chain of err, while the code,
+// the HTTP status and the extra data are the first ones set in the chain, so wrapped errors are supported.
+// Errors without translatable message are rendered with the status text as message,
+// their own message being only included in debug mode.
+func (r *ErrorRenderer) Render(c *gin.Context, err error) {
+	resp := r.Response(c.Request.Context(), err)
+	c.AbortWithStatusJSON(resp.HttpStatus, r.cfg.Envelope(c, resp))
+}
+
+// Handler returns a middleware rendering the last error of the Gin context,
+// when the handlers add an error with c.Error without writing a response
+func (r *ErrorRenderer) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		r.Render(c, c.Errors.Last().Err)
+	}
+}
+
+// Response returns the content of the response of the error, translated in the language of the context
+func (r *ErrorRenderer) Response(ctx context.Context, err error) ErrorResponse {
+	resp := ErrorResponse{
+		HttpStatus: errors.HttpStatusDefault,
+		Code:       errors.CodeDefault,
+		Extra:      map[string]any{},
+	}
+
+	if r.cfg.Debug() {
+		resp.Cause = err.Error()
+	}
+
+	resp.HttpStatus = errors.HttpStatus(err)
+	resp.Code = errors.Code(err)
+
+	translatable := i18nhttp.TranslatableError(err)
+	if translatable == nil {
+		resp.Message = http.StatusText(resp.HttpStatus)
+		return resp
+	}
+
+	resp.Message = translatable.(types.Translator).T(ctx)
+
+	for _, key := range r.cfg.Extra {
+		if value, ok := i18nhttp.ErrorExtra(err, key); ok {
+			resp.Extra[key] = i18nhttp.TranslateExtra(ctx, value)
+		}
+	}
+
+	return resp
+}
+
+func defaultEnvelope(c *gin.Context, resp ErrorResponse) any {
+	body := gin.H{}
+	for key, value := range resp.Extra {
+		body[key] = value
+	}
+
+	body["code"] = resp.Code
+	body["message"] = resp.Message
+	if resp.Cause != "" {
+		body["cause"] = resp.Cause
+	}
+
+	return body
+}
@@ -0,0 +1,60 @@
+package i18ngin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/epkgs/i18n"
+	"github.com/epkgs/i18n/errors"
+)
+
+func TestErrorResponse(t *testing.T) {
+	n, err := i18n.NewKV(map[string]map[string]string{
+		"en":    {"User not found": "User not found"},
+		"zh-CN": {"User not found": "用户不存在"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notFound := errors.WithCode(errors.WithHttpStatus(n.Bundle("user").Err("User not found"), http.StatusNotFound), 1001)
+	ctx := i18n.WithAcceptLanguages(context.Background(), "zh-CN")
+
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantCode    int
+		wantMessage string
+	}{
+		{"error", notFound, http.StatusNotFound, 1001, "用户不存在"},
+		// 包装的错误保留原错误的状态码和错误码
+		{"wrapped", errors.Wrap(notFound, "find user"), http.StatusNotFound, 1001, "find user"},
+		{"fmt wrapped", fmt.Errorf("find user: %w", notFound), http.StatusNotFound, 1001, "用户不存在"},
+		// 外层设置的状态码优先
+		{"overridden", errors.WithHttpStatus(errors.Wrap(notFound, "gone"), http.StatusGone), http.StatusGone, 1001, "gone"},
+		{"plain", fmt.Errorf("db down"), http.StatusInternalServerError, errors.CodeDefault, "Internal Server Error"},
+	}
+
+	renderer := NewErrorRenderer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := renderer.Response(ctx, tt.err)
+
+			if resp.HttpStatus != tt.wantStatus || resp.Code != tt.wantCode || resp.Message != tt.wantMessage {
+				t.Errorf("Response() = %d %d %q, want %d %d %q", resp.HttpStatus, resp.Code, resp.Message, tt.wantStatus, tt.wantCode, tt.wantMessage)
+			}
+			// 默认不包含错误原因
+			if resp.Cause != "" {
+				t.Errorf("Cause = %q, want empty", resp.Cause)
+			}
+		})
+	}
+
+	renderer = NewErrorRenderer(func(c *ErrorConfig) { c.Debug = func() bool { return true } })
+	if resp := renderer.Response(ctx, fmt.Errorf("db down")); resp.Cause != "db down" {
+		t.Errorf("Cause in debug = %q, want %q", resp.Cause, "db down")
+	}
+}
@@ -0,0 +1,112 @@
+// Package i18ngin provides the Gin integration of the i18n package,
+// built on the net/http integration of the i18nhttp package.
+package i18ngin
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/epkgs/i18n/i18nhttp"
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware is a Gin framework middleware for handling internationalization, see i18nhttp.Middleware
+// defaultLangs: default languages to use when no language is specified in query, cookie, or accept-language header
+func Middleware(defaultLangs ...string) gin.HandlerFunc {
+	return Wrap(i18nhttp.Middleware(defaultLangs...))
+}
+
+// New is a Gin framework middleware for handling internationalization, configured with the config functions,
+// see i18nhttp.New
+func New(config ...func(c *i18nhttp.Config)) gin.HandlerFunc {
+	return Wrap(i18nhttp.New(config...))
+}
+
+// Wrap adapts a net/http middleware to Gin: the request it passes to the next handler,
+// with its context, becomes the request of the Gin context, and so does its writer,
+// e.g. a compressing writer, while the next handlers run.
+// The Gin chain is aborted when the middleware does not call the next handler.
+//
+// The writer of the Gin context is wrapped to give HTMLRender access to the request.
+func Wrap(middleware func(http.Handler) http.Handler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := c.Writer.(*requestWriter); !ok {
+			c.Writer = &requestWriter{ResponseWriter: c.Writer, c: c}
+		}
+
+		writer := c.Writer
+		called := false
+
+		middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			c.Request = r
+
+			if w != http.ResponseWriter(writer) {
+				c.Writer = &requestWriter{ResponseWriter: writer, c: c, w: w}
+				defer func() { c.Writer = writer }()
+			}
+
+			c.Next()
+		})).ServeHTTP(writer, c.Request)
+
+		if !called {
+			c.Abort()
+		}
+	}
+}
+
+// requestWriter is the writer of a Gin context, giving the renderers access to its request.
+// The response is written to w when a net/http middleware passed its own writer to the next handler,
+// the state of the response, e.g. its status, is still tracked by the Gin writer it wraps.
+type requestWriter struct {
+	gin.ResponseWriter
+	c *gin.Context
+	w http.ResponseWriter // writer of the net/http middleware, nil when it passed the Gin writer
+}
+
+func (w *requestWriter) Header() http.Header {
+	if w.w == nil {
+		return w.ResponseWriter.Header()
+	}
+	return w.w.Header()
+}
+
+func (w *requestWriter) WriteHeader(code int) {
+	if w.w == nil {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.w.WriteHeader(code)
+}
+
+func (w *requestWriter) Write(data []byte) (int, error) {
+	if w.w == nil {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.w.Write(data)
+}
+
+func (w *requestWriter) WriteString(s string) (int, error) {
+	if w.w == nil {
+		return w.ResponseWriter.WriteString(s)
+	}
+	return io.WriteString(w.w, s)
+}
+
+func (w *requestWriter) Flush() {
+	if w.w == nil {
+		w.ResponseWriter.Flush()
+		return
+	}
+	if f, ok := w.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap returns the writer the response is written to, for http.ResponseController
+func (w *requestWriter) Unwrap() http.ResponseWriter {
+	if w.w == nil {
+		return w.ResponseWriter
+	}
+	return w.w
+}
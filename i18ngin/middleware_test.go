@@ -0,0 +1,84 @@
+package i18ngin
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/epkgs/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+func TestWrap(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(Wrap(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Has("deny") {
+				http.Error(w, "denied", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(i18n.WithAcceptLanguages(r.Context(), "zh-CN")))
+		})
+	}))
+	r.GET("/", func(c *gin.Context) {
+		// 中间件传递的请求成为 Gin 上下文的请求
+		c.String(http.StatusOK, "%v", i18n.GetAcceptLanguages(c.Request.Context()))
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if got := w.Body.String(); got != "[zh-CN]" {
+		t.Errorf("body = %q, want %q", got, "[zh-CN]")
+	}
+
+	// 中间件未调用下一个处理器时中止
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?deny", nil))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
+
+// upperWriter writes the response in upper case, like a net/http middleware rewriting the response
+type upperWriter struct {
+	http.ResponseWriter
+}
+
+func (w upperWriter) Write(data []byte) (int, error) {
+	return w.ResponseWriter.Write(bytes.ToUpper(data))
+}
+
+func TestWrapWriter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(Wrap(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Upper", "1")
+			next.ServeHTTP(upperWriter{w}, r)
+		})
+	}))
+	r.GET("/", func(c *gin.Context) {
+		c.String(http.StatusCreated, "hello")
+	})
+	r.GET("/status", func(c *gin.Context) {
+		c.Status(http.StatusAccepted)
+		c.Writer.WriteHeaderNow()
+	})
+
+	// 中间件传递的 writer 成为 Gin 上下文的 writer
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusCreated || w.Body.String() != "HELLO" || w.Header().Get("X-Upper") != "1" {
+		t.Errorf("response = %d %q %v, want 201 %q with X-Upper", w.Code, w.Body.String(), w.Header(), "HELLO")
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/status", nil))
+	if w.Code != http.StatusAccepted {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusAccepted)
+	}
+}
@@ -0,0 +1,19 @@
+package i18ngin
+
+import (
+	"github.com/epkgs/i18n/i18nhttp"
+	"github.com/gin-gonic/gin"
+)
+
+// RenderProblem renders the problem details (RFC 9457) of the error in the language of the request
+// and aborts the Gin chain, see i18nhttp.NewProblem.
+// The content type is application/problem+json, or application/json when the client only accepts the latter.
+//
+//	if err := svc.Login(ctx, req); err != nil {
+//		i18ngin.RenderProblem(c, err)
+//		return
+//	}
+func RenderProblem(c *gin.Context, err error, config ...func(c *i18nhttp.ProblemConfig)) {
+	i18nhttp.WriteProblem(c.Writer, c.Request, err, config...)
+	c.Abort()
+}
@@ -0,0 +1,90 @@
+package i18ngin
+
+import (
+	"context"
+	htmltemplate "html/template"
+	"net/http"
+
+	"github.com/epkgs/i18n"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/render"
+)
+
+// HTMLRender is a gin render.HTMLRender which binds the localized template
+// functions of I18n.FuncMap to every rendered template.
+//
+// The templates must be parsed with the functions of I18n.FuncMap, HTMLRenderGlob does it for you.
+type HTMLRender struct {
+	i18n     *i18n.I18n
+	template *htmltemplate.Template
+}
+
+// NewHTMLRender returns a HTMLRender for templates parsed with the functions of I18n.FuncMap.
+func NewHTMLRender(n *i18n.I18n, tmpl *htmltemplate.Template) *HTMLRender {
+	return &HTMLRender{
+		i18n:     n,
+		template: tmpl,
+	}
+}
+
+// HTMLRenderGlob parses the templates matched by pattern and returns a HTMLRender for them.
+func HTMLRenderGlob(n *i18n.I18n, pattern string) (*HTMLRender, error) {
+	tmpl, err := htmltemplate.New("").Funcs(n.FuncMap(context.Background())).ParseGlob(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewHTMLRender(n, tmpl), nil
+}
+
+// Instance implements render.HTMLRender, so c.HTML renders the templates in the language of the request.
+// Since gin does not pass the request to the renderer, it is read from the writer installed by the middlewares
+// of this package, see Wrap: without them, the template functions are bound to the default language.
+func (r *HTMLRender) Instance(name string, data any) render.Render {
+	return &requestHTML{render: r, name: name, data: data}
+}
+
+// HTML renders the named template with the template functions bound to the language of the request.
+//
+//	r.GET("/", func(c *gin.Context) {
+//		htmlRender.HTML(c, http.StatusOK, "index.html", gin.H{"Name": "alice"})
+//	})
+func (r *HTMLRender) HTML(c *gin.Context, code int, name string, data any) {
+	c.Render(code, r.instance(c.Request.Context(), name, data))
+}
+
+func (r *HTMLRender) instance(ctx context.Context, name string, data any) render.Render {
+	tmpl := r.template
+
+	// The original template is never executed, so cloning it always succeeds.
+	if clone, err := r.template.Clone(); err == nil {
+		tmpl = clone.Funcs(r.i18n.FuncMap(ctx))
+	}
+
+	return render.HTML{
+		Template: tmpl,
+		Name:     name,
+		Data:     data,
+	}
+}
+
+// requestHTML renders a template with the template functions bound to the language of the request,
+// read from the writer it is rendered to
+type requestHTML struct {
+	render *HTMLRender
+	name   string
+	data   any
+}
+
+func (h *requestHTML) Render(w http.ResponseWriter) error {
+	ctx := context.Background()
+	if rw, ok := w.(*requestWriter); ok {
+		ctx = rw.c.Request.Context()
+	}
+
+	return h.render.instance(ctx, h.name, h.data).Render(w)
+}
+
+func (h *requestHTML) WriteContentType(w http.ResponseWriter) {
+	render.HTML{}.WriteContentType(w)
+}
@@ -0,0 +1,44 @@
+package i18ngin
+
+import (
+	"context"
+	htmltemplate "html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/epkgs/i18n"
+	"github.com/epkgs/i18n/i18nhttp"
+	"github.com/gin-gonic/gin"
+)
+
+func TestHTMLRenderInstance(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	n, err := i18n.NewKV(map[string]map[string]string{
+		"en":    {"Hello %s": "Hello %s"},
+		"zh-CN": {"Hello %s": "你好 %s"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := htmltemplate.Must(htmltemplate.New("index.html").Funcs(n.FuncMap(context.Background())).Parse(`{{ t "user" "Hello %s" .Name }}`))
+
+	r := gin.New()
+	r.HTMLRender = NewHTMLRender(n, tmpl)
+	r.Use(New(func(c *i18nhttp.Config) { c.I18n = n }))
+	r.GET("/", func(c *gin.Context) {
+		c.HTML(http.StatusOK, "index.html", gin.H{"Name": "alice"})
+	})
+
+	// c.HTML 使用请求的语言
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "zh-CN")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if got := w.Body.String(); got != "你好 alice" {
+		t.Errorf("body = %q, want %q", got, "你好 alice")
+	}
+}
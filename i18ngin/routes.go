@@ -0,0 +1,229 @@
+package i18ngin
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"net/http"
+	"strings"
+
+	"github.com/epkgs/i18n"
+	"github.com/epkgs/i18n/i18nhttp"
+	"github.com/gin-gonic/gin"
+)
+
+// RoutesConfig is the configuration of Localized routes
+type RoutesConfig struct {
+	// I18n is the instance whose languages are negotiated, default: the default instance
+	I18n *i18n.I18n
+
+	// Languages are the language prefixes, e.g. "en" and "zh-CN", default: the languages of the instance
+	Languages []string
+
+	// Resolvers resolve the preferred languages of a request without prefix, to redirect it.
+	// Default: cookie `lang`, the Accept-Language header
+	Resolvers []i18nhttp.Resolver
+
+	// BaseURL is prepended to the localized URLs, e.g. "https://example.com", as hreflang links should be absolute
+	BaseURL string
+
+	// RedirectCode is the status code of the redirects to the prefixed paths, default: http.StatusFound
+	RedirectCode int
+}
+
+// Localized serves the same routes under a path prefix per language, e.g. `/en/users` and `/zh-CN/users`
+type Localized struct {
+	i18n      *i18n.I18n
+	langs     []string
+	resolver  i18nhttp.Resolver
+	baseURL   string
+	code      int
+	groups    []*gin.RouterGroup
+	languages map[string]string // normalised language -> prefix
+}
+
+// Alternate is a localized URL of the current page, see Localized.Alternates
+type Alternate struct {
+	Lang string // language of the page, "x-default" for the page without prefix
+	Href string
+}
+
+// Localize creates a route group per language under r, see Localized.Handle.
+// r should be the engine, as the paths of the requests are expected to start with the language prefix.
+//
+//	loc := i18ngin.Localize(r)
+//	loc.Handle(func(g *gin.RouterGroup) {
+//		g.GET("/users", listUsers) // /en/users, /zh-CN/users...
+//	})
+//	r.NoRoute(loc.Redirect) // /users -> /zh-CN/users
+func Localize(r gin.IRouter, config ...func(c *RoutesConfig)) *Localized {
+	cfg := &RoutesConfig{
+		I18n:         i18n.Default(),
+		RedirectCode: http.StatusFound,
+		Resolvers: []i18nhttp.Resolver{
+			i18nhttp.Cookie("lang"),
+			i18nhttp.AcceptLanguage(),
+		},
+	}
+	for _, fn := range config {
+		fn(cfg)
+	}
+
+	langs := cfg.Languages
+	if len(langs) == 0 {
+		for _, info := range cfg.I18n.Languages() {
+			langs = append(langs, info.Code)
+		}
+	}
+
+	l := &Localized{
+		i18n:      cfg.I18n,
+		resolver:  i18nhttp.Chain(cfg.Resolvers...),
+		baseURL:   strings.TrimSuffix(cfg.BaseURL, "/"),
+		code:      cfg.RedirectCode,
+		languages: map[string]string{},
+	}
+
+	for _, lang := range langs {
+		tag, err := i18n.NormalizeLanguage(lang)
+		if err != nil {
+			panic(fmt.Sprintf("i18ngin: invalid language prefix %q: %v", lang, err))
+		}
+		if _, ok := l.languages[tag.String()]; ok {
+			continue
+		}
+
+		l.langs = append(l.langs, lang)
+		l.languages[tag.String()] = lang
+
+		// The language of the prefix is stored in the context of the request
+		prefixLang := []string{tag.String()}
+		l.groups = append(l.groups, r.Group("/"+lang, New(func(c *i18nhttp.Config) {
+			c.I18n = cfg.I18n
+			c.Resolvers = []i18nhttp.Resolver{i18nhttp.ResolverFunc("path", func(r *http.Request) []string {
+				return prefixLang
+			})}
+			c.DisableCookie = true
+		})))
+	}
+
+	return l
+}
+
+// Languages returns the language prefixes
+func (l *Localized) Languages() []string {
+	return append([]string(nil), l.langs...)
+}
+
+// Handle registers the routes of register in the route group of every language
+func (l *Localized) Handle(register func(g *gin.RouterGroup)) {
+	for _, g := range l.groups {
+		register(g)
+	}
+}
+
+// Group returns the route group of the language prefix, or nil if it is not one of the languages
+func (l *Localized) Group(lang string) *gin.RouterGroup {
+	for i, prefix := range l.langs {
+		if prefix == lang {
+			return l.groups[i]
+		}
+	}
+	return nil
+}
+
+// StripPrefix returns the language prefix of the path and the path without it,
+// the prefix is empty if the path does not start with one of the languages.
+// The first segment of the path is normalised, so `/zh-cn/users` has the prefix `zh-CN`.
+func (l *Localized) StripPrefix(path string) (prefix, rest string) {
+	prefix, _, rest = l.stripPrefix(path)
+	return prefix, rest
+}
+
+// stripPrefix implements StripPrefix, segment is the first segment of the path matching the prefix
+func (l *Localized) stripPrefix(path string) (prefix, segment, rest string) {
+	segment, rest, _ = strings.Cut(strings.TrimPrefix(path, "/"), "/")
+
+	if tag, err := i18n.NormalizeLanguage(segment); err == nil {
+		if prefix, ok := l.languages[tag.String()]; ok {
+			return prefix, segment, "/" + rest
+		}
+	}
+
+	return "", "", path
+}
+
+// Path returns the path prefixed with the language
+func (l *Localized) Path(lang, path string) string {
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return "/" + lang + path
+}
+
+// URL returns the URL of the current page in the language
+func (l *Localized) URL(c *gin.Context, lang string) string {
+	_, path := l.StripPrefix(c.Request.URL.Path)
+	return l.baseURL + l.Path(lang, path)
+}
+
+// Alternates returns the URLs of the current page in every language, and the page without prefix as "x-default"
+func (l *Localized) Alternates(c *gin.Context) []Alternate {
+	_, path := l.StripPrefix(c.Request.URL.Path)
+
+	alternates := make([]Alternate, 0, len(l.langs)+1)
+	for _, lang := range l.langs {
+		alternates = append(alternates, Alternate{Lang: lang, Href: l.baseURL + l.Path(lang, path)})
+	}
+	alternates = append(alternates, Alternate{Lang: "x-default", Href: l.baseURL + path})
+
+	return alternates
+}
+
+// HreflangLinks returns the `<link rel="alternate" hreflang="...">` elements of the current page, for the HTML head
+func (l *Localized) HreflangLinks(c *gin.Context) htmltemplate.HTML {
+	var b strings.Builder
+
+	for _, a := range l.Alternates(c) {
+		fmt.Fprintf(&b, `<link rel="alternate" hreflang="%s" href="%s">`+"\n",
+			htmltemplate.HTMLEscapeString(a.Lang), htmltemplate.HTMLEscapeString(a.Href))
+	}
+
+	return htmltemplate.HTML(b.String())
+}
+
+// Redirect redirects the GET and HEAD requests of paths without language prefix
+// to the path prefixed with the best matching language, it is meant to be the NoRoute handler.
+// The paths prefixed with another spelling of a language, e.g. `/zh-cn/users`, are redirected to the prefix of the language.
+// The other requests are left unhandled.
+func (l *Localized) Redirect(c *gin.Context) {
+	if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+		return
+	}
+
+	if prefix, segment, rest := l.stripPrefix(c.Request.URL.Path); prefix != "" || len(l.langs) == 0 {
+		if prefix != segment {
+			l.redirect(c, l.Path(prefix, rest))
+		}
+		return
+	}
+
+	// the default language of the instance when no preferred language matches
+	lang := l.langs[0]
+	tag, _, _ := l.i18n.Negotiate(l.resolver.Resolve(c.Request)...)
+	if prefix, found := l.languages[tag.String()]; found {
+		lang = prefix
+	}
+
+	c.Header("Vary", "Accept-Language, Cookie")
+	l.redirect(c, l.Path(lang, c.Request.URL.Path))
+}
+
+// redirect redirects the request to the path, keeping its query
+func (l *Localized) redirect(c *gin.Context, path string) {
+	u := *c.Request.URL
+	u.Path = path
+	u.RawPath = ""
+
+	c.Redirect(l.code, u.RequestURI())
+	c.Abort()
+}
@@ -0,0 +1,151 @@
+package i18ngin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/epkgs/i18n"
+	"github.com/epkgs/i18n/i18nhttp"
+	"github.com/gin-gonic/gin"
+)
+
+func newTestRoutes(t *testing.T) (*gin.Engine, *Localized) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	n, err := i18n.NewKV(map[string]map[string]string{
+		"en":    {"Hello": "Hello"},
+		"zh-CN": {"Hello": "你好"},
+		"ja":    {"Hello": "こんにちは"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := gin.New()
+	loc := Localize(r, func(c *RoutesConfig) {
+		c.I18n = n
+		c.Languages = []string{"en", "zh-CN"}
+		c.BaseURL = "https://example.com/"
+	})
+	loc.Handle(func(g *gin.RouterGroup) {
+		g.GET("/hello", func(c *gin.Context) {
+			ctx := c.Request.Context()
+			c.String(http.StatusOK, "%s %s", n.Bundle("test").Str("Hello").T(ctx), i18nhttp.LanguageSource(ctx))
+		})
+		g.GET("/links", func(c *gin.Context) {
+			c.String(http.StatusOK, "%s", loc.HreflangLinks(c))
+		})
+	})
+	r.NoRoute(loc.Redirect)
+
+	return r, loc
+}
+
+func TestLocalizedRoutes(t *testing.T) {
+	r, _ := newTestRoutes(t)
+
+	tests := []struct {
+		path     string
+		accept   string
+		wantLang string
+		want     string
+	}{
+		// 路径前缀决定语言，优先于 Accept-Language
+		{"/zh-CN/hello", "en", "zh-CN", "你好 path"},
+		{"/en/hello", "zh-CN", "en", "Hello path"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+		req.Header.Set("Accept-Language", tt.accept)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK || w.Body.String() != tt.want {
+			t.Errorf("%s: %d %q, want 200 %q", tt.path, w.Code, w.Body.String(), tt.want)
+		}
+		if got := w.Header().Get("Content-Language"); got != tt.wantLang {
+			t.Errorf("%s: Content-Language = %q, want %q", tt.path, got, tt.wantLang)
+		}
+	}
+}
+
+func TestLocalizedRedirect(t *testing.T) {
+	r, _ := newTestRoutes(t)
+
+	tests := []struct {
+		name         string
+		method       string
+		target       string
+		accept       string
+		cookie       string
+		wantCode     int
+		wantLocation string
+		wantVary     string
+	}{
+		{"accept-language", http.MethodGet, "/hello?page=2", "fr, zh-CN;q=0.8", "", http.StatusFound, "/zh-CN/hello?page=2", "Accept-Language, Cookie"},
+		{"cookie first", http.MethodGet, "/hello", "zh-CN", "en", http.StatusFound, "/en/hello", "Accept-Language, Cookie"},
+		{"default language", http.MethodGet, "/hello", "fr", "", http.StatusFound, "/en/hello", "Accept-Language, Cookie"},
+		{"regional language", http.MethodHead, "/hello", "zh-Hans-CN", "", http.StatusFound, "/zh-CN/hello", "Accept-Language, Cookie"},
+		// 其他写法的语言前缀重定向到规范的前缀，与请求的语言无关
+		{"lowercase prefix", http.MethodGet, "/zh-cn/hello?page=2", "en", "", http.StatusFound, "/zh-CN/hello?page=2", ""},
+		{"posix prefix", http.MethodGet, "/zh_CN/hello", "en", "", http.StatusFound, "/zh-CN/hello", ""},
+		{"other methods", http.MethodPost, "/hello", "zh-CN", "", http.StatusNotFound, "", ""},
+		{"prefixed path", http.MethodGet, "/zh-CN/missing", "en", "", http.StatusNotFound, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			req.Header.Set("Accept-Language", tt.accept)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "lang", Value: tt.cookie})
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode || w.Header().Get("Location") != tt.wantLocation {
+				t.Errorf("%d %q, want %d %q", w.Code, w.Header().Get("Location"), tt.wantCode, tt.wantLocation)
+			}
+			// 重定向依赖请求的语言
+			if got := w.Header().Get("Vary"); got != tt.wantVary {
+				t.Errorf("Vary = %q, want %q", got, tt.wantVary)
+			}
+		})
+	}
+}
+
+func TestLocalizedURLs(t *testing.T) {
+	r, loc := newTestRoutes(t)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/zh-CN/links", nil))
+
+	want := `<link rel="alternate" hreflang="en" href="https://example.com/en/links">` + "\n" +
+		`<link rel="alternate" hreflang="zh-CN" href="https://example.com/zh-CN/links">` + "\n" +
+		`<link rel="alternate" hreflang="x-default" href="https://example.com/links">` + "\n"
+	if got := w.Body.String(); got != want {
+		t.Errorf("hreflang links = %q, want %q", got, want)
+	}
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/en/users/1", nil)
+	if got := loc.URL(c, "zh-CN"); got != "https://example.com/zh-CN/users/1" {
+		t.Errorf("URL = %q, want %q", got, "https://example.com/zh-CN/users/1")
+	}
+
+	if prefix, rest := loc.StripPrefix("/zh-CN/users"); prefix != "zh-CN" || rest != "/users" {
+		t.Errorf("StripPrefix = %q, %q, want %q, %q", prefix, rest, "zh-CN", "/users")
+	}
+	if prefix, rest := loc.StripPrefix("/zh-cn/users"); prefix != "zh-CN" || rest != "/users" {
+		t.Errorf("StripPrefix = %q, %q, want %q, %q", prefix, rest, "zh-CN", "/users")
+	}
+	if prefix, rest := loc.StripPrefix("/users"); prefix != "" || rest != "/users" {
+		t.Errorf("StripPrefix = %q, %q, want %q, %q", prefix, rest, "", "/users")
+	}
+	if got := loc.Path("en", "users"); got != "/en/users" {
+		t.Errorf("Path = %q, want %q", got, "/en/users")
+	}
+}
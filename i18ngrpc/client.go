@@ -0,0 +1,43 @@
+package i18ngrpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/epkgs/i18n"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryClientInterceptor returns a client interceptor which forwards the languages of the caller,
+// stored in the context with i18n.WithAcceptLanguages, in the `accept-language` metadata.
+// The metadata set by the caller is kept.
+//
+//	grpc.NewClient(target, grpc.WithChainUnaryInterceptor(i18ngrpc.UnaryClientInterceptor()))
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(OutgoingLanguages(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor is the stream counterpart of UnaryClientInterceptor
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(OutgoingLanguages(ctx), desc, cc, method, opts...)
+	}
+}
+
+// OutgoingLanguages returns a context with the languages of the caller in the outgoing `accept-language` metadata,
+// unless it is already set
+func OutgoingLanguages(ctx context.Context) context.Context {
+	langs := i18n.GetAcceptLanguages(ctx)
+	if len(langs) == 0 {
+		return ctx
+	}
+
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(MetadataAcceptLanguage)) > 0 {
+		return ctx
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, MetadataAcceptLanguage, strings.Join(langs, ", "))
+}
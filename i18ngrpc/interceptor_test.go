@@ -0,0 +1,197 @@
+package i18ngrpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/epkgs/i18n"
+	"github.com/epkgs/i18n/errors"
+	"github.com/epkgs/i18n/types"
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// testService answers with the languages of the caller, or fails with a translatable error
+type testService struct {
+	bundle types.Bundler
+}
+
+var testServiceDesc = grpc.ServiceDesc{
+	ServiceName: "i18ngrpc.Test",
+	HandlerType: (*any)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Languages",
+			Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+				in := new(wrapperspb.StringValue)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				handler := func(ctx context.Context, req any) (any, error) {
+					if req.(*wrapperspb.StringValue).Value == "fail" {
+						return nil, srv.(*testService).bundle.Err("User %s not exist", "alice")
+					}
+					langs := i18n.GetAcceptLanguages(ctx)
+					if len(langs) == 0 {
+						return wrapperspb.String(""), nil
+					}
+					return wrapperspb.String(langs[0]), nil
+				}
+				return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: "/i18ngrpc.Test/Languages"}, handler)
+			},
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Stream",
+			ServerStreams: true,
+			Handler: func(srv any, stream grpc.ServerStream) error {
+				return srv.(*testService).bundle.Err("User %s not exist", "bob")
+			},
+		},
+	},
+}
+
+// dial starts a server with the interceptors over an in-process connection and returns a client connection
+func dial(t *testing.T, n *i18n.I18n) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(UnaryServerInterceptor(func(c *Config) { c.I18n = n })),
+		grpc.ChainStreamInterceptor(StreamServerInterceptor(func(c *Config) { c.I18n = n })),
+	)
+	server.RegisterService(&testServiceDesc, &testService{bundle: n.Bundle("user")})
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(StreamClientInterceptor()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func newTestI18n(t *testing.T) *i18n.I18n {
+	t.Helper()
+
+	n, err := i18n.NewKV(map[string]map[string]string{
+		"en":    {"User %s not exist": "User %s not exist"},
+		"zh-CN": {"User %s not exist": "用户 %s 不存在"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestUnaryLanguages(t *testing.T) {
+	n := newTestI18n(t)
+	conn := dial(t, n)
+
+	// 客户端拦截器转发上下文中的语言
+	ctx := i18n.WithAcceptLanguages(context.Background(), "zh_CN")
+
+	out := new(wrapperspb.StringValue)
+	if err := conn.Invoke(ctx, "/i18ngrpc.Test/Languages", wrapperspb.String(""), out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Value != "zh-CN" {
+		t.Errorf("language = %q, want %q", out.Value, "zh-CN")
+	}
+}
+
+func TestUnaryError(t *testing.T) {
+	n := newTestI18n(t)
+	conn := dial(t, n)
+
+	ctx := i18n.WithAcceptLanguages(context.Background(), "zh-CN")
+
+	err := conn.Invoke(ctx, "/i18ngrpc.Test/Languages", wrapperspb.String("fail"), new(wrapperspb.StringValue))
+	assertLocalized(t, err, codes.Internal, "zh-CN", "用户 alice 不存在")
+}
+
+func TestStreamError(t *testing.T) {
+	n := newTestI18n(t)
+	conn := dial(t, n)
+
+	ctx := i18n.WithAcceptLanguages(context.Background(), "en")
+
+	stream, err := conn.NewStream(ctx, &testServiceDesc.Streams[0], "/i18ngrpc.Test/Stream")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+
+	err = stream.RecvMsg(new(wrapperspb.StringValue))
+	assertLocalized(t, err, codes.Internal, "en", "User bob not exist")
+}
+
+func TestStatusCode(t *testing.T) {
+	n := newTestI18n(t)
+
+	err := errors.WithHttpStatus(n.Bundle("user").Err("User %s not exist", "alice"), 404)
+
+	st := Status(context.Background(), n, err, nil)
+	if st.Code() != codes.NotFound {
+		t.Errorf("code = %v, want %v", st.Code(), codes.NotFound)
+	}
+
+	if st := Status(context.Background(), n, context.Canceled, nil); st != nil {
+		t.Errorf("status of a plain error = %v, want nil", st)
+	}
+}
+
+func TestStatusBundleLanguage(t *testing.T) {
+	n := newTestI18n(t)
+
+	// 包的默认语言与实例不同时，报告翻译消息所用的语言
+	bundle := n.Bundle("user")
+	bundle.SetDefaultLanguage(language.Make("zh-CN"))
+
+	ctx := i18n.WithAcceptLanguages(context.Background(), "fr")
+	st := Status(ctx, n, bundle.Err("User %s not exist", "alice"), nil)
+
+	assertLocalized(t, st.Err(), codes.Internal, "zh-CN", "用户 alice 不存在")
+}
+
+func assertLocalized(t *testing.T, err error, code codes.Code, locale, msg string) {
+	t.Helper()
+
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("error %v is not a status", err)
+	}
+	if st.Code() != code {
+		t.Errorf("code = %v, want %v", st.Code(), code)
+	}
+
+	for _, detail := range st.Details() {
+		if lm, ok := detail.(*errdetails.LocalizedMessage); ok {
+			if lm.Locale != locale || lm.Message != msg {
+				t.Errorf("localized message = %q %q, want %q %q", lm.Locale, lm.Message, locale, msg)
+			}
+			return
+		}
+	}
+	t.Errorf("no localized message in %v", st.Details())
+}
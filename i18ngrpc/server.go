@@ -0,0 +1,245 @@
+// Package i18ngrpc provides the gRPC integration of the i18n package:
+// server interceptors storing the languages of the caller in the context and translating the returned errors,
+// and client interceptors forwarding the languages of the caller.
+package i18ngrpc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/epkgs/i18n"
+	"github.com/epkgs/i18n/errors"
+	"github.com/epkgs/i18n/types"
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata keys of the languages of the caller, by order of preference
+const (
+	MetadataAcceptLanguage     = "accept-language"
+	MetadataGRPCAcceptLanguage = "grpc-accept-language"
+)
+
+// Config is the configuration of the server interceptors
+type Config struct {
+	// I18n is the instance negotiating the locale of the translated errors, default: the default instance
+	I18n *i18n.I18n
+
+	// DefaultLanguages are used when the caller sends no language
+	DefaultLanguages []string
+
+	// Code returns the status code of a translatable error, default: CodeFromHTTPStatus of errors.HttpStatus
+	Code func(err error) codes.Code
+}
+
+func newConfig(config ...func(c *Config)) *Config {
+	cfg := &Config{
+		Code: defaultCode,
+	}
+	for _, fn := range config {
+		fn(cfg)
+	}
+	return cfg
+}
+
+// defaultCode maps the HTTP status of the error, see errors.HttpStatus
+func defaultCode(err error) codes.Code {
+	return CodeFromHTTPStatus(errors.HttpStatus(err))
+}
+
+// instance returns the instance negotiating the locale of the translated errors
+func (cfg *Config) instance() *i18n.I18n {
+	if cfg.I18n != nil {
+		return cfg.I18n
+	}
+	return i18n.Default()
+}
+
+// UnaryServerInterceptor returns a server interceptor which stores the languages of the caller in the context,
+// see i18n.GetAcceptLanguages, and converts the returned types.Error to a status with a translated
+// errdetails.LocalizedMessage, see Status.
+//
+//	grpc.NewServer(grpc.ChainUnaryInterceptor(i18ngrpc.UnaryServerInterceptor()))
+func UnaryServerInterceptor(config ...func(c *Config)) grpc.UnaryServerInterceptor {
+	cfg := newConfig(config...)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx = cfg.withLanguages(ctx)
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, cfg.status(ctx, err)
+		}
+		return resp, nil
+	}
+}
+
+// StreamServerInterceptor is the stream counterpart of UnaryServerInterceptor
+func StreamServerInterceptor(config ...func(c *Config)) grpc.StreamServerInterceptor {
+	cfg := newConfig(config...)
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := cfg.withLanguages(ss.Context())
+
+		if err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx}); err != nil {
+			return cfg.status(ctx, err)
+		}
+		return nil
+	}
+}
+
+// serverStream is a grpc.ServerStream with the context holding the languages of the caller
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// withLanguages stores the languages of the caller in the context
+func (cfg *Config) withLanguages(ctx context.Context) context.Context {
+	langs := IncomingLanguages(ctx)
+	if len(langs) == 0 {
+		langs = cfg.DefaultLanguages
+	}
+	if len(langs) == 0 {
+		return ctx
+	}
+	return i18n.WithAcceptLanguages(ctx, langs...)
+}
+
+// status converts the error with Status
+func (cfg *Config) status(ctx context.Context, err error) error {
+	st := Status(ctx, cfg.instance(), err, cfg.Code)
+	if st == nil {
+		return err
+	}
+	return st.Err()
+}
+
+// IncomingLanguages returns the languages of the caller from the incoming metadata,
+// `accept-language` first, then `grpc-accept-language`, by decreasing q-weight
+func IncomingLanguages(ctx context.Context) []string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	langs := []string{}
+	for _, key := range []string{MetadataAcceptLanguage, MetadataGRPCAcceptLanguage} {
+		for _, value := range md.Get(key) {
+			// some clients send POSIX style locales, e.g. "pt_br"
+			tags, _, err := language.ParseAcceptLanguage(strings.ReplaceAll(value, "_", "-"))
+			if err != nil {
+				continue
+			}
+			for _, tag := range tags {
+				if t, err := i18n.NormalizeLanguage(tag.String()); err == nil && !contains(langs, t.String()) {
+					langs = append(langs, t.String())
+				}
+			}
+		}
+	}
+
+	return langs
+}
+
+// Status converts the first types.Error in the chain of err to a status with the code returned by code
+// (by default the mapping of its HTTP status), its untranslated message, and an errdetails.LocalizedMessage
+// holding the message translated in the language of the context, negotiated with n.
+// It returns nil when the chain holds no types.Error, the errors which already are a status are returned as is.
+func Status(ctx context.Context, n *i18n.I18n, err error, code func(err error) codes.Code) *status.Status {
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+
+	var e types.Error
+	if !errors.As(err, &e) {
+		return nil
+	}
+
+	if code == nil {
+		code = defaultCode
+	}
+
+	st := status.New(code(e), e.String())
+
+	// the language the message is translated in, the bundle of the message may have its own default language
+	locale := n.LocalizerCtx(ctx).Language()
+	if m, ok := e.(interface {
+		Language(ctx context.Context) language.Tag
+	}); ok {
+		if tag := m.Language(ctx); tag != language.Und {
+			locale = tag
+		}
+	}
+
+	if detailed, err := st.WithDetails(&errdetails.LocalizedMessage{
+		Locale:  locale.String(),
+		Message: e.T(ctx),
+	}); err == nil {
+		st = detailed
+	}
+
+	return st
+}
+
+// CodeFromHTTPStatus returns the status code corresponding to the HTTP status,
+// as mapped by the gRPC-HTTP transcoding of google.rpc.Code
+func CodeFromHTTPStatus(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusOK:
+		return codes.OK
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusPreconditionFailed:
+		return codes.FailedPrecondition
+	case http.StatusRequestedRangeNotSatisfiable:
+		return codes.OutOfRange
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case 499: // client closed request
+		return codes.Canceled
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	case http.StatusInternalServerError:
+		return codes.Internal
+	}
+
+	switch {
+	case httpStatus >= 400 && httpStatus < 500:
+		return codes.FailedPrecondition
+	case httpStatus >= 500:
+		return codes.Internal
+	}
+
+	return codes.Unknown
+}
+
+func contains(langs []string, lang string) bool {
+	for _, l := range langs {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,264 @@
+// Package i18nhttp provides the net/http integration of the i18n package,
+// usable with any router built on net/http, such as chi or gorilla/mux.
+package i18nhttp
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/epkgs/i18n"
+	"golang.org/x/text/language"
+)
+
+// Language identifier sources
+const (
+	headerAcceptLanguage  = "Accept-Language"
+	headerContentLanguage = "Content-Language"
+	headerVary            = "Vary"
+	queryLang             = "lang"
+	cookieLang            = "lang"
+)
+
+// Config is the configuration of the middleware
+type Config struct {
+	// Resolvers resolve the preferred languages of the request, in order of preference.
+	// Default: query QueryParam, cookie CookieName, the Accept-Language header
+	Resolvers []Resolver
+
+	// DefaultLanguages are used when no resolver resolved a supported language, their source is "default"
+	DefaultLanguages []string
+
+	// I18n is the instance whose languages are negotiated, default: the default instance when the request is served
+	I18n *i18n.I18n
+
+	// QueryParam is the query parameter selecting the language, default: "lang"
+	QueryParam string
+
+	// CookieName is the cookie persisting the language selected with the query parameter, default: "lang"
+	CookieName string
+
+	// CookieMaxAge is the lifetime of the cookie, default: 0, a session cookie
+	CookieMaxAge time.Duration
+
+	// CookieDomain is the domain of the cookie, default: the host of the request
+	CookieDomain string
+
+	// CookiePath is the path of the cookie, default: "/"
+	CookiePath string
+
+	// CookieSecure sends the cookie over HTTPS only
+	CookieSecure bool
+
+	// CookieSameSite is the SameSite attribute of the cookie, default: http.SameSiteLaxMode
+	CookieSameSite http.SameSite
+
+	// DisableCookie disables the cookie: the selected language is neither persisted nor read from it
+	DisableCookie bool
+}
+
+// sourceKey is the context key of the source of the preferred language
+type sourceKey struct{}
+
+// instanceKey is the context key of the instance of the middleware, available to the resolvers
+type instanceKey struct{}
+
+// instanceFromContext returns the instance of the middleware serving the request, or the default instance
+func instanceFromContext(ctx context.Context) *i18n.I18n {
+	if n, ok := ctx.Value(instanceKey{}).(*i18n.I18n); ok {
+		return n
+	}
+	return i18n.Default()
+}
+
+// LanguageSource returns the name of the resolver of the negotiated language of the request, e.g. "query" or "cookie",
+// "default" when no preferred language is supported, or an empty string outside the middleware.
+func LanguageSource(ctx context.Context) string {
+	source, _ := ctx.Value(sourceKey{}).(string)
+	return source
+}
+
+// New returns a net/http middleware for handling internationalization, configured with the config functions.
+// It negotiates the preferred languages of the request against the languages of the instance,
+// stores the chosen language in its context, see i18n.GetAcceptLanguages,
+// and sets the Content-Language and Vary response headers.
+//
+//	i18nhttp.New(func(c *i18nhttp.Config) {
+//		c.Resolvers = []i18nhttp.Resolver{
+//			i18nhttp.PathPrefix(),
+//			i18nhttp.Header("X-Locale"),
+//			i18nhttp.AcceptLanguage(),
+//		}
+//	})
+func New(config ...func(c *Config)) func(http.Handler) http.Handler {
+	cfg := &Config{
+		QueryParam:     queryLang,
+		CookieName:     cookieLang,
+		CookiePath:     "/",
+		CookieSameSite: http.SameSiteLaxMode,
+	}
+	for _, fn := range config {
+		fn(cfg)
+	}
+
+	resolvers := append([]Resolver{}, cfg.Resolvers...)
+	if len(resolvers) == 0 {
+		resolvers = append(resolvers, Query(cfg.QueryParam))
+		if !cfg.DisableCookie {
+			resolvers = append(resolvers, Cookie(cfg.CookieName))
+		}
+		resolvers = append(resolvers, AcceptLanguage())
+	}
+	defaults := []Resolver{Default(cfg.DefaultLanguages...)}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+			n := cfg.instance()
+			r = r.WithContext(context.WithValue(r.Context(), instanceKey{}, n))
+			resolvedLangs := resolve(r, resolvers)
+
+			// Negotiate against the languages of the instance, by order of preference:
+			// the resolvers first, then the default languages, then the default language of the instance
+			tag, source, ok := negotiate(n, resolvedLangs)
+			if !ok {
+				tag, source, ok = negotiate(n, resolve(r, defaults))
+			}
+			if !ok {
+				source = "default"
+			}
+
+			// The chosen language first, then the other preferences
+			langs := []string{tag.String()}
+			for _, l := range resolvedLangs {
+				if l.lang != tag.String() {
+					langs = append(langs, l.lang)
+				}
+
+				// If language is set via URL parameter, save it to cookie
+				if l.source == "query" && !cfg.DisableCookie {
+					cfg.setCookie(w, r, n, l.lang)
+				}
+			}
+
+			// Tell the caches the response depends on the language
+			w.Header().Set(headerContentLanguage, tag.String())
+			addVary(w.Header(), headerAcceptLanguage)
+			if !cfg.DisableCookie {
+				addVary(w.Header(), "Cookie")
+			}
+
+			ctx := context.WithValue(r.Context(), sourceKey{}, source)
+
+			// Set language to context
+			next.ServeHTTP(w, r.WithContext(i18n.WithAcceptLanguages(ctx, langs...)))
+		})
+	}
+}
+
+// setCookie persists the language selected with the query parameter,
+// only if it is a supported language and the cookie does not hold it already
+func (cfg *Config) setCookie(w http.ResponseWriter, r *http.Request, n *i18n.I18n, lang string) {
+	tag, _, ok := n.Negotiate(lang)
+	if !ok {
+		return
+	}
+	lang = tag.String()
+
+	if cookie, err := r.Cookie(cfg.CookieName); err == nil && normalizeLanguage(cookie.Value) == lang {
+		return
+	}
+
+	cookie := &http.Cookie{
+		Name:     cfg.CookieName,
+		Value:    lang,
+		Path:     cfg.CookiePath,
+		Domain:   cfg.CookieDomain,
+		Secure:   cfg.CookieSecure,
+		HttpOnly: true,
+		SameSite: cfg.CookieSameSite,
+	}
+	if cfg.CookieMaxAge > 0 {
+		cookie.MaxAge = int(cfg.CookieMaxAge.Seconds())
+		cookie.Expires = time.Now().Add(cfg.CookieMaxAge)
+	}
+
+	http.SetCookie(w, cookie)
+}
+
+// instance returns the instance whose languages are negotiated
+func (cfg *Config) instance() *i18n.I18n {
+	if cfg.I18n != nil {
+		return cfg.I18n
+	}
+	return i18n.Default()
+}
+
+// negotiate returns the supported language of the first resolved language matching one, and its source
+func negotiate(n *i18n.I18n, resolvedLangs []resolved) (tag language.Tag, source string, ok bool) {
+	langs := make([]string, len(resolvedLangs))
+	for i, l := range resolvedLangs {
+		langs[i] = l.lang
+	}
+
+	tag, i, ok := n.Negotiate(langs...)
+	if !ok {
+		return tag, "", false
+	}
+
+	return tag, resolvedLangs[i].source, true
+}
+
+// addVary adds the header to the Vary header, unless it is already there
+func addVary(h http.Header, header string) {
+	for _, v := range h.Values(headerVary) {
+		for _, field := range strings.Split(v, ",") {
+			if field = strings.TrimSpace(field); field == "*" || strings.EqualFold(field, header) {
+				return
+			}
+		}
+	}
+	h.Add(headerVary, header)
+}
+
+// Middleware is a net/http middleware for handling internationalization, see New.
+// Search order: 1. URL parameter 2. Cookie 3. Accept-Language header 4. Default language
+// defaultLangs: default languages to use when no language is specified in query, cookie, or accept-language header
+func Middleware(defaultLangs ...string) func(http.Handler) http.Handler {
+	return New(func(c *Config) {
+		c.DefaultLanguages = defaultLangs
+	})
+}
+
+// parseAcceptLanguages parses the Accept-Language header and returns the best matching languages
+func parseAcceptLanguages(acceptLanguage string) []string {
+	langs := []string{}
+
+	// some clients send POSIX style locales, e.g. "pt_br"
+	acceptLanguage = strings.ReplaceAll(acceptLanguage, "_", "-")
+
+	if langTags, _, err := language.ParseAcceptLanguage(acceptLanguage); err == nil {
+		for _, tag := range langTags {
+			if lang := normalizeLanguage(tag.String()); lang != "" {
+				langs = append(langs, lang)
+			}
+		}
+	}
+
+	return langs
+}
+
+// normalizeLanguage returns the normalised language code, or an empty string if the language is invalid
+func normalizeLanguage(lang string) string {
+	if lang == "" {
+		return ""
+	}
+
+	tag, err := i18n.NormalizeLanguage(lang)
+	if err != nil {
+		return ""
+	}
+
+	return tag.String()
+}
@@ -0,0 +1,220 @@
+package i18nhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/epkgs/i18n"
+)
+
+func newTestI18n(t *testing.T) *i18n.I18n {
+	t.Helper()
+
+	n, err := i18n.NewKV(map[string]map[string]string{
+		"en":    {"Hello": "Hello"},
+		"zh-CN": {"Hello": "你好"},
+		"ja":    {"Hello": "こんにちは"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+// serve serves the request with the middleware and returns the response,
+// the languages stored in the context and the source of the negotiated language
+func serve(t *testing.T, middleware func(http.Handler) http.Handler, req *http.Request) (w *httptest.ResponseRecorder, langs []string, source string) {
+	t.Helper()
+
+	w = httptest.NewRecorder()
+	middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		langs = i18n.GetAcceptLanguages(r.Context())
+		source = LanguageSource(r.Context())
+	})).ServeHTTP(w, req)
+
+	return w, langs, source
+}
+
+func TestMiddlewareSources(t *testing.T) {
+	n := newTestI18n(t)
+	middleware := New(func(c *Config) { c.I18n = n })
+
+	tests := []struct {
+		name       string
+		url        string
+		cookie     string
+		accept     string
+		wantLang   string
+		wantSource string
+	}{
+		{"query", "/?lang=ja", "zh-CN", "zh-CN", "ja", "query"},
+		{"cookie", "/", "ja", "zh-CN", "ja", "cookie"},
+		{"accept-language", "/", "", "fr, zh-CN;q=0.8", "zh-CN", "accept-language"},
+		{"posix locale", "/", "", "zh_CN", "zh-CN", "accept-language"},
+		{"none", "/", "", "", "en", "default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "lang", Value: tt.cookie})
+			}
+			if tt.accept != "" {
+				req.Header.Set("Accept-Language", tt.accept)
+			}
+
+			_, langs, source := serve(t, middleware, req)
+
+			// 协商的语言在上下文中排在第一位
+			if len(langs) == 0 || langs[0] != tt.wantLang {
+				t.Errorf("languages = %v, want %q first", langs, tt.wantLang)
+			}
+			if source != tt.wantSource {
+				t.Errorf("source = %q, want %q", source, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestMiddlewareDefaultLanguages(t *testing.T) {
+	n := newTestI18n(t)
+	middleware := New(func(c *Config) {
+		c.I18n = n
+		c.DefaultLanguages = []string{"ja"}
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "fr")
+
+	_, langs, source := serve(t, middleware, req)
+
+	// 请求的语言都不支持时使用默认语言
+	if len(langs) == 0 || langs[0] != "ja" || source != "default" {
+		t.Errorf("languages = %v, source = %q, want ja first from default", langs, source)
+	}
+}
+
+func TestMiddlewareCookie(t *testing.T) {
+	n := newTestI18n(t)
+	middleware := New(func(c *Config) {
+		c.I18n = n
+		c.QueryParam = "locale"
+		c.CookieName = "i18n"
+		c.CookieMaxAge = time.Hour
+		c.CookieDomain = "example.com"
+		c.CookieSecure = true
+		c.CookieSameSite = http.SameSiteStrictMode
+	})
+
+	w, _, _ := serve(t, middleware, httptest.NewRequest(http.MethodGet, "/?locale=zh_cn", nil))
+
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("cookies = %v, want 1 cookie", cookies)
+	}
+	c := cookies[0]
+	if c.Name != "i18n" || c.Value != "zh-CN" || c.MaxAge != 3600 || c.Domain != "example.com" || c.Path != "/" ||
+		!c.Secure || !c.HttpOnly || c.SameSite != http.SameSiteStrictMode {
+		t.Errorf("cookie = %+v", c)
+	}
+
+	// 不支持的语言不写入 Cookie
+	w, _, _ = serve(t, middleware, httptest.NewRequest(http.MethodGet, "/?locale=fr", nil))
+	if cookies := w.Result().Cookies(); len(cookies) != 0 {
+		t.Errorf("cookies for an unsupported language = %v, want none", cookies)
+	}
+
+	// Cookie 已是该语言时不再写入
+	req := httptest.NewRequest(http.MethodGet, "/?locale=ja", nil)
+	req.AddCookie(&http.Cookie{Name: "i18n", Value: "ja"})
+	w, _, _ = serve(t, middleware, req)
+	if cookies := w.Result().Cookies(); len(cookies) != 0 {
+		t.Errorf("cookies when unchanged = %v, want none", cookies)
+	}
+}
+
+func TestMiddlewareDisableCookie(t *testing.T) {
+	n := newTestI18n(t)
+	middleware := New(func(c *Config) {
+		c.I18n = n
+		c.DisableCookie = true
+	})
+
+	w, _, _ := serve(t, middleware, httptest.NewRequest(http.MethodGet, "/?lang=ja", nil))
+	if cookies := w.Result().Cookies(); len(cookies) != 0 {
+		t.Errorf("cookies = %v, want none", cookies)
+	}
+
+	// 禁用后不读取 Cookie
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "lang", Value: "ja"})
+	req.Header.Set("Accept-Language", "zh-CN")
+	_, langs, source := serve(t, middleware, req)
+	if len(langs) == 0 || langs[0] != "zh-CN" || source != "accept-language" {
+		t.Errorf("languages = %v, source = %q, want zh-CN first from accept-language", langs, source)
+	}
+}
+
+func TestMiddlewareNegotiation(t *testing.T) {
+	n := newTestI18n(t)
+	middleware := New(func(c *Config) { c.I18n = n })
+
+	tests := []struct {
+		accept    string
+		wantLangs []string
+	}{
+		// 按 q 值排序，跳过不支持的语言，其余偏好排在协商的语言之后
+		{"fr, ja;q=0.9, zh-CN;q=0.8", []string{"ja", "fr", "zh-CN"}},
+		{"zh-CN;q=0.5, ja;q=0.9", []string{"ja", "zh-CN"}},
+		// 地区语言匹配支持的语言
+		{"en-US", []string{"en", "en-US"}},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Language", tt.accept)
+
+		w, langs, _ := serve(t, middleware, req)
+
+		if !reflect.DeepEqual(langs, tt.wantLangs) {
+			t.Errorf("%q: languages = %v, want %v", tt.accept, langs, tt.wantLangs)
+		}
+		if got := w.Header().Get("Content-Language"); got != tt.wantLangs[0] {
+			t.Errorf("%q: Content-Language = %q, want %q", tt.accept, got, tt.wantLangs[0])
+		}
+	}
+}
+
+func TestMiddlewareVary(t *testing.T) {
+	n := newTestI18n(t)
+
+	handler := New(func(c *Config) { c.I18n = n })(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	w := httptest.NewRecorder()
+	w.Header().Set("Vary", "Origin, accept-language")
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// 已有的 Vary 值不重复添加
+	if got := w.Header().Values("Vary"); !reflect.DeepEqual(got, []string{"Origin, accept-language", "Cookie"}) {
+		t.Errorf("Vary = %q, want %q", got, []string{"Origin, accept-language", "Cookie"})
+	}
+
+	// 禁用 Cookie 时不依赖 Cookie
+	handler = New(func(c *Config) {
+		c.I18n = n
+		c.DisableCookie = true
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if got := w.Header().Values("Vary"); !reflect.DeepEqual(got, []string{"Accept-Language"}) {
+		t.Errorf("Vary = %q, want %q", got, []string{"Accept-Language"})
+	}
+	if got := w.Header().Get("Content-Language"); got != "en" {
+		t.Errorf("Content-Language = %q, want %q", got, "en")
+	}
+}
@@ -0,0 +1,223 @@
+package i18nhttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/epkgs/i18n"
+	"github.com/epkgs/i18n/errors"
+	"github.com/epkgs/i18n/types"
+)
+
+// Content types of the problem details documents
+const (
+	ContentTypeProblem = "application/problem+json"
+	ContentTypeJSON    = "application/json"
+)
+
+// ProblemBundle is the bundle translating the default titles of the problems, keyed by the HTTP status text,
+// e.g. "Not Found"
+const ProblemBundle = "http"
+
+var problemBundle = i18n.Bundle(ProblemBundle)
+
+// ProblemConfig is the configuration of the problem details of the errors
+type ProblemConfig struct {
+	// Extensions are the keys of the extra data of the errors included as extension members, see types.Storager.
+	// The translatable values are translated. Default: "code" set by errors.WithCode and errors.ExtraFields
+	Extensions []string
+}
+
+// Problem is a problem details document, as defined by RFC 9457
+type Problem struct {
+	Type     string // URI identifying the problem type, "about:blank" when empty
+	Title    string // short summary of the problem type, translated
+	Status   int    // HTTP status
+	Detail   string // explanation of this occurrence of the problem, translated
+	Instance string // URI identifying this occurrence of the problem
+
+	// Extensions are the additional members, they do not override the standard ones
+	Extensions map[string]any
+}
+
+// MarshalJSON encodes the problem with its extension members
+func (p Problem) MarshalJSON() ([]byte, error) {
+	members := make(map[string]any, len(p.Extensions)+5)
+	for key, value := range p.Extensions {
+		members[key] = value
+	}
+
+	members["status"] = p.Status
+	for key, value := range map[string]string{"type": p.Type, "title": p.Title, "detail": p.Detail, "instance": p.Instance} {
+		if value != "" {
+			members[key] = value
+		} else {
+			delete(members, key)
+		}
+	}
+
+	return json.Marshal(members)
+}
+
+// NewProblem returns the problem details of the error, translated in the language of the context.
+//
+// The problem is made of the first data set in the chain of err, so wrapped errors are supported:
+//   - status: errors.HttpStatus
+//   - detail: the translated message of the first translatable error
+//   - type and title: the extra data set with errors.WithProblemType and errors.WithTitle,
+//     the title defaults to the HTTP status text, translated with the bundle ProblemBundle
+//   - instance: the extra data errors.ExtraInstance
+//   - extension members: the extra data of ProblemConfig.Extensions, translated
+//
+// Errors without translatable message have no detail, their message is not disclosed.
+func NewProblem(ctx context.Context, err error, config ...func(c *ProblemConfig)) Problem {
+	cfg := &ProblemConfig{
+		Extensions: []string{"code", errors.ExtraFields},
+	}
+	for _, fn := range config {
+		fn(cfg)
+	}
+
+	p := Problem{
+		Status:     errors.HttpStatus(err),
+		Extensions: map[string]any{},
+	}
+
+	if translatable := TranslatableError(err); translatable != nil {
+		p.Detail = translatable.(types.Translator).T(ctx)
+	}
+
+	if value, ok := ErrorExtra(err, errors.ExtraProblemType); ok {
+		p.Type = toString(ctx, value)
+	}
+	if value, ok := ErrorExtra(err, errors.ExtraTitle); ok {
+		p.Title = toString(ctx, value)
+	}
+	if value, ok := ErrorExtra(err, errors.ExtraInstance); ok {
+		p.Instance = toString(ctx, value)
+	}
+
+	for _, key := range cfg.Extensions {
+		if value, ok := ErrorExtra(err, key); ok {
+			p.Extensions[key] = TranslateExtra(ctx, value)
+		}
+	}
+
+	if p.Title == "" {
+		p.Title = problemBundle.Str(http.StatusText(p.Status)).T(ctx)
+	}
+
+	return p
+}
+
+// WriteProblem writes the problem details of the error, translated in the language of the request.
+// The content type is application/problem+json, or application/json when the client only accepts the latter.
+//
+//	if err := svc.Login(r.Context(), req); err != nil {
+//		i18nhttp.WriteProblem(w, r, err)
+//		return
+//	}
+func WriteProblem(w http.ResponseWriter, r *http.Request, err error, config ...func(c *ProblemConfig)) {
+	p := NewProblem(r.Context(), err, config...)
+
+	body, marshalErr := json.Marshal(p)
+	if marshalErr != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType(r))
+	addVary(w.Header(), "Accept")
+	w.WriteHeader(p.Status)
+	w.Write(body)
+}
+
+// ProblemContentType negotiates the content type of a problem details document with the Accept header of the request:
+// application/problem+json, unless application/json is accepted and application/problem+json is not
+func ProblemContentType(r *http.Request) string {
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return ContentTypeProblem
+	}
+
+	var problemQ, jsonQ, wildcardQ float64 = -1, -1, -1
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, _ := strings.Cut(part, ";")
+		mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			if name, value, ok := strings.Cut(strings.TrimSpace(param), "="); ok && strings.TrimSpace(name) == "q" {
+				if v, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+					q = v
+				}
+			}
+		}
+
+		switch mediaType {
+		case ContentTypeProblem:
+			problemQ = max(problemQ, q)
+		case ContentTypeJSON:
+			jsonQ = max(jsonQ, q)
+		case "application/*", "*/*":
+			wildcardQ = max(wildcardQ, q)
+		}
+	}
+
+	if problemQ < 0 {
+		problemQ = wildcardQ
+	}
+	if jsonQ > 0 && jsonQ > problemQ {
+		return ContentTypeJSON
+	}
+
+	return ContentTypeProblem
+}
+
+// TranslatableError returns the first error of the chain which is translatable, or nil
+func TranslatableError(err error) error {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if _, ok := err.(types.Translator); ok {
+			return err
+		}
+	}
+	return nil
+}
+
+// ErrorExtra returns the extra data of the first error of the chain which has the key set, see types.Storager
+func ErrorExtra(err error, key string) (any, bool) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if storage, ok := err.(types.Storager); ok && storage.Has(key) {
+			return storage.Get(key, nil), true
+		}
+	}
+	return nil, false
+}
+
+// TranslateExtra translates the extra data of an error, e.g. a types.Stringer or the i18nvalidator.FieldErrors
+func TranslateExtra(ctx context.Context, v any) any {
+	switch t := v.(type) {
+	case types.Translator:
+		return t.T(ctx)
+	case interface {
+		T(ctx context.Context) map[string]string
+	}:
+		return t.T(ctx)
+	}
+	return v
+}
+
+// toString returns the extra data as a string, translated if it is translatable
+func toString(ctx context.Context, v any) string {
+	switch t := TranslateExtra(ctx, v).(type) {
+	case string:
+		return t
+	case interface{ String() string }:
+		return t.String()
+	}
+	return ""
+}
@@ -0,0 +1,88 @@
+package i18nhttp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/epkgs/i18n/errors"
+)
+
+func TestWriteProblem(t *testing.T) {
+	n := newTestI18n(t)
+	middleware := New(func(c *Config) { c.I18n = n })
+
+	err := n.Bundle("test").Err("Hello")
+	err = errors.WithHttpStatus(err, http.StatusForbidden)
+	err = errors.WithCode(err, 1001)
+	err = errors.WithProblemType(err, "https://example.com/probs/out-of-credit")
+	err.Set("balance", 30)
+	err.Set("secret", "internal")
+
+	tests := []struct {
+		name   string
+		err    error
+		config []func(c *ProblemConfig)
+		want   map[string]any
+	}{
+		// 默认只包含错误码，不包含其他附加数据
+		{"default", err, nil, map[string]any{
+			"type": "https://example.com/probs/out-of-credit", "title": "Forbidden", "status": 403.0, "detail": "こんにちは", "code": 1001.0,
+		}},
+		{"extensions", err, []func(c *ProblemConfig){func(c *ProblemConfig) { c.Extensions = []string{"balance"} }}, map[string]any{
+			"type": "https://example.com/probs/out-of-credit", "title": "Forbidden", "status": 403.0, "detail": "こんにちは", "balance": 30.0,
+		}},
+		// 包装的错误保留原错误的状态码和附加数据
+		{"wrapped", fmt.Errorf("login: %w", err), nil, map[string]any{
+			"type": "https://example.com/probs/out-of-credit", "title": "Forbidden", "status": 403.0, "detail": "こんにちは", "code": 1001.0,
+		}},
+		{"plain", fmt.Errorf("db down"), nil, map[string]any{
+			"title": "Internal Server Error", "status": 500.0,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/?lang=ja", nil)
+			w := httptest.NewRecorder()
+			middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				WriteProblem(w, r, tt.err, tt.config...)
+			})).ServeHTTP(w, req)
+
+			var got map[string]any
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			if w.Code != int(tt.want["status"].(float64)) || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%d %v, want %v", w.Code, got, tt.want)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != ContentTypeProblem {
+				t.Errorf("Content-Type = %q, want %q", ct, ContentTypeProblem)
+			}
+		})
+	}
+}
+
+func TestProblemContentType(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"", ContentTypeProblem},
+		{"application/json", ContentTypeJSON},
+		{"application/problem+json, application/json", ContentTypeProblem},
+		{"application/json, */*;q=0.5", ContentTypeJSON},
+		{"application/json;q=0.5, application/*", ContentTypeProblem},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept", tt.accept)
+		if got := ProblemContentType(req); got != tt.want {
+			t.Errorf("%q: ProblemContentType() = %q, want %q", tt.accept, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package i18nhttp
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/epkgs/i18n"
+	"golang.org/x/text/language"
+)
+
+// Resolver resolves the preferred languages of a request from one source, e.g. a query parameter or a cookie
+type Resolver interface {
+	// Name returns the name of the source, recorded in the context for the resolved language, see LanguageSource
+	Name() string
+
+	// Resolve returns the preferred languages of the request, most preferred first, or nil
+	Resolve(r *http.Request) []string
+}
+
+// resolverFunc is a Resolver implemented by a function
+type resolverFunc struct {
+	name    string
+	resolve func(r *http.Request) []string
+}
+
+func (f *resolverFunc) Name() string {
+	return f.name
+}
+
+func (f *resolverFunc) Resolve(r *http.Request) []string {
+	return f.resolve(r)
+}
+
+// ResolverFunc returns a Resolver named name which resolves the languages with the function
+func ResolverFunc(name string, resolve func(r *http.Request) []string) Resolver {
+	return &resolverFunc{name: name, resolve: resolve}
+}
+
+// single returns a resolver of a single language, ignored when empty or invalid
+func single(name string, resolve func(r *http.Request) string) Resolver {
+	return ResolverFunc(name, func(r *http.Request) []string {
+		if lang := normalizeLanguage(resolve(r)); lang != "" {
+			return []string{lang}
+		}
+		return nil
+	})
+}
+
+// Query resolves the language from the query parameter, e.g. `?lang=zh-CN`
+func Query(param string) Resolver {
+	return single("query", func(r *http.Request) string {
+		return r.URL.Query().Get(param)
+	})
+}
+
+// Cookie resolves the language from the cookie
+func Cookie(name string) Resolver {
+	return single("cookie", func(r *http.Request) string {
+		if cookie, err := r.Cookie(name); err == nil {
+			return cookie.Value
+		}
+		return ""
+	})
+}
+
+// Header resolves the language from a custom request header, e.g. `X-Locale: zh-CN`
+func Header(name string) Resolver {
+	return single("header", func(r *http.Request) string {
+		return r.Header.Get(name)
+	})
+}
+
+// AcceptLanguage resolves the languages from the Accept-Language header, by decreasing q-weight
+func AcceptLanguage() Resolver {
+	return ResolverFunc("accept-language", func(r *http.Request) []string {
+		return parseAcceptLanguages(r.Header.Get(headerAcceptLanguage))
+	})
+}
+
+// PathPrefix resolves the language from the first segment of the URL path, e.g. `/zh-CN/users`.
+// Only the supported languages are accepted, by default those of the instance of the middleware.
+func PathPrefix(supported ...string) Resolver {
+	isSupported := supportedLanguages(supported)
+	return single("path", func(r *http.Request) string {
+		segment, _, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+		return isSupported(r, segment)
+	})
+}
+
+// Subdomain resolves the language from the first label of the host, e.g. `zh-cn.example.com`.
+// Only the supported languages are accepted, by default those of the instance of the middleware.
+func Subdomain(supported ...string) Resolver {
+	isSupported := supportedLanguages(supported)
+	return single("subdomain", func(r *http.Request) string {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+
+		label, _, found := strings.Cut(host, ".")
+		if !found {
+			return ""
+		}
+		return isSupported(r, label)
+	})
+}
+
+// User resolves the language of the logged-in user, e.g. from the claims of its JWT or from its profile,
+// which are usually stored in the request context by an authentication middleware.
+func User(lang func(r *http.Request) string) Resolver {
+	return single("user", lang)
+}
+
+// Tenant resolves the default language of the tenant of the request
+func Tenant(lang func(r *http.Request) string) Resolver {
+	return single("tenant", lang)
+}
+
+// Default resolves the given languages, usually the last resolver of a chain
+func Default(langs ...string) Resolver {
+	return ResolverFunc("default", func(r *http.Request) []string {
+		resolved := []string{}
+		for _, lang := range langs {
+			if lang := normalizeLanguage(lang); lang != "" {
+				resolved = append(resolved, lang)
+			}
+		}
+		return resolved
+	})
+}
+
+// chain is an ordered composition of resolvers
+type chain []Resolver
+
+// Chain composes the resolvers in order: the languages of the first ones are preferred,
+// and the source of each language is the resolver which resolved it.
+func Chain(resolvers ...Resolver) Resolver {
+	return chain(resolvers)
+}
+
+func (c chain) Name() string {
+	return "chain"
+}
+
+func (c chain) Resolve(r *http.Request) []string {
+	langs := []string{}
+	for _, l := range resolve(r, c) {
+		langs = append(langs, l.lang)
+	}
+	return langs
+}
+
+// resolved is a language resolved by a Resolver
+type resolved struct {
+	lang   string
+	source string
+}
+
+// resolve runs the resolvers in order and returns the languages of all of them, without duplicates
+func resolve(r *http.Request, resolvers []Resolver) []resolved {
+	langs := []resolved{}
+
+	for _, resolver := range resolvers {
+		var resolverLangs []resolved
+		if c, ok := resolver.(chain); ok {
+			resolverLangs = resolve(r, c)
+		} else {
+			for _, lang := range resolver.Resolve(r) {
+				resolverLangs = append(resolverLangs, resolved{lang: lang, source: resolver.Name()})
+			}
+		}
+
+		for _, rl := range resolverLangs {
+			lang := rl.lang
+			duplicate := false
+			for _, l := range langs {
+				if l.lang == lang {
+					duplicate = true
+					break
+				}
+			}
+			if !duplicate {
+				langs = append(langs, rl)
+			}
+		}
+	}
+
+	return langs
+}
+
+// supportedLanguages returns a function returning the normalised language if it is one of the supported languages,
+// or an empty string. The supported languages are normalised once, by default they are the languages
+// of the instance of the middleware, see instanceFromContext.
+func supportedLanguages(supported []string) func(r *http.Request, lang string) string {
+	tags := make(map[language.Tag]bool, len(supported))
+	for _, s := range supported {
+		if t, err := i18n.NormalizeLanguage(s); err == nil {
+			tags[t] = true
+		}
+	}
+
+	return func(r *http.Request, lang string) string {
+		tag, err := i18n.NormalizeLanguage(lang)
+		if err != nil {
+			return ""
+		}
+
+		if len(supported) > 0 {
+			if tags[tag] {
+				return tag.String()
+			}
+			return ""
+		}
+
+		// the matched language must be the language itself, e.g. not en for en-GB
+		if matched, _, ok := instanceFromContext(r.Context()).Negotiate(tag.String()); ok && matched == tag {
+			return tag.String()
+		}
+		return ""
+	}
+}
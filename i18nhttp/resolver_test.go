@@ -0,0 +1,91 @@
+package i18nhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestResolvers(t *testing.T) {
+	tests := []struct {
+		name     string
+		resolver Resolver
+		url      string
+		header   map[string]string
+		want     []string
+	}{
+		{"query", Query("locale"), "/?locale=zh_cn", nil, []string{"zh-CN"}},
+		{"query invalid", Query("lang"), "/?lang=not-a-language!", nil, nil},
+		{"header", Header("X-Locale"), "/", map[string]string{"X-Locale": "ja"}, []string{"ja"}},
+		{"accept-language by q-weight", AcceptLanguage(), "/", map[string]string{"Accept-Language": "en;q=0.5, zh-CN, ja;q=0.8"}, []string{"zh-CN", "ja", "en"}},
+		{"path prefix", PathPrefix("en", "zh-CN"), "/zh-CN/users", nil, []string{"zh-CN"}},
+		{"path prefix unsupported", PathPrefix("en", "zh-CN"), "/fr/users", nil, nil},
+		{"path without prefix", PathPrefix("en", "zh-CN"), "/users", nil, nil},
+		{"subdomain", Subdomain("en", "zh-CN"), "http://zh-cn.example.com/", nil, []string{"zh-CN"}},
+		{"subdomain with port", Subdomain("en", "zh-CN"), "http://en.example.com:8080/", nil, []string{"en"}},
+		{"subdomain unsupported", Subdomain("en", "zh-CN"), "http://www.example.com/", nil, nil},
+		{"default", Default("ja", "invalid!"), "/", nil, []string{"ja"}},
+		{"user", User(func(r *http.Request) string { return r.Header.Get("X-User-Lang") }), "/", map[string]string{"X-User-Lang": "ja"}, []string{"ja"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			for key, value := range tt.header {
+				req.Header.Set(key, value)
+			}
+
+			if got := tt.resolver.Resolve(req); len(got) != len(tt.want) || (len(got) > 0 && !reflect.DeepEqual(got, tt.want)) {
+				t.Errorf("Resolve() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/?lang=ja", nil)
+	req.Header.Set("X-Locale", "zh-CN")
+	req.Header.Set("Accept-Language", "ja, en")
+
+	// 按顺序合并，重复的语言保留最先解析的来源
+	resolvers := []Resolver{Chain(Query("lang"), Header("X-Locale")), AcceptLanguage()}
+
+	want := []resolved{{"ja", "query"}, {"zh-CN", "header"}, {"en", "accept-language"}}
+	if got := resolve(req, resolvers); !reflect.DeepEqual(got, want) {
+		t.Errorf("resolve() = %v, want %v", got, want)
+	}
+
+	if got := Chain(resolvers...).Resolve(req); !reflect.DeepEqual(got, []string{"ja", "zh-CN", "en"}) {
+		t.Errorf("Chain().Resolve() = %v, want [ja zh-CN en]", got)
+	}
+}
+
+func TestResolversOfInstance(t *testing.T) {
+	n := newTestI18n(t)
+
+	middleware := New(func(c *Config) {
+		c.I18n = n
+		c.Resolvers = []Resolver{PathPrefix(), Subdomain(), Tenant(func(r *http.Request) string { return "ja" })}
+	})
+
+	tests := []struct {
+		url        string
+		wantLang   string
+		wantSource string
+	}{
+		// 未指定支持的语言时使用中间件实例的语言
+		{"http://example.com/zh-CN/users", "zh-CN", "path"},
+		{"http://zh-cn.example.com/users", "zh-CN", "subdomain"},
+		{"http://example.com/fr/users", "ja", "tenant"},
+		{"http://example.com/en-GB/users", "ja", "tenant"},
+	}
+
+	for _, tt := range tests {
+		_, langs, source := serve(t, middleware, httptest.NewRequest(http.MethodGet, tt.url, nil))
+
+		if len(langs) == 0 || langs[0] != tt.wantLang || source != tt.wantSource {
+			t.Errorf("%s: languages = %v, source = %q, want %q first from %q", tt.url, langs, source, tt.wantLang, tt.wantSource)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package i18nvalidator
+
+import (
+	"github.com/epkgs/i18n"
+)
+
+// Module is the module path under which RegisterCatalog registers the catalog, see i18n.RegisterModule
+const Module = "github.com/epkgs/i18n/i18nvalidator"
+
+// Messages of the standard validation tags, by tag and optionally the kind of the field:
+// ".string" for strings, ".items" for slices, arrays and maps.
+// The messages are templates with the fields Field, Param, Value and Tag, they are the keys of the translations.
+var messages = map[string]string{
+	"":                 "{{.Field}} is invalid",
+	"required":         "{{.Field}} is required",
+	"required_if":      "{{.Field}} is required",
+	"required_unless":  "{{.Field}} is required",
+	"required_with":    "{{.Field}} is required",
+	"required_without": "{{.Field}} is required",
+	"email":            "{{.Field}} must be a valid email address",
+	"url":              "{{.Field}} must be a valid URL",
+	"http_url":         "{{.Field}} must be a valid URL",
+	"uri":              "{{.Field}} must be a valid URL",
+	"uuid":             "{{.Field}} must be a valid UUID",
+	"uuid4":            "{{.Field}} must be a valid UUID",
+	"ip":               "{{.Field}} must be a valid IP address",
+	"ipv4":             "{{.Field}} must be a valid IP address",
+	"ipv6":             "{{.Field}} must be a valid IP address",
+	"numeric":          "{{.Field}} must be a number",
+	"number":           "{{.Field}} must be a number",
+	"alpha":            "{{.Field}} can only contain letters",
+	"alphanum":         "{{.Field}} can only contain letters and numbers",
+	"boolean":          "{{.Field}} must be a boolean",
+	"datetime":         "{{.Field}} must be a date in the format {{.Param}}",
+	"oneof":            "{{.Field}} must be one of {{.Param}}",
+	"contains":         "{{.Field}} must contain {{.Param}}",
+	"len":              "{{.Field}} must be equal to {{.Param}}",
+	"len.string":       "{{.Field}} must be exactly {{.Param}} characters long",
+	"len.items":        "{{.Field}} must contain exactly {{.Param}} items",
+	"eq":               "{{.Field}} must be equal to {{.Param}}",
+	"eqfield":          "{{.Field}} must be equal to {{.Param}}",
+	"ne":               "{{.Field}} must not be equal to {{.Param}}",
+	"nefield":          "{{.Field}} must not be equal to {{.Param}}",
+	"min":              "{{.Field}} must be at least {{.Param}}",
+	"min.string":       "{{.Field}} must be at least {{.Param}} characters long",
+	"min.items":        "{{.Field}} must contain at least {{.Param}} items",
+	"gte":              "{{.Field}} must be at least {{.Param}}",
+	"gte.string":       "{{.Field}} must be at least {{.Param}} characters long",
+	"gte.items":        "{{.Field}} must contain at least {{.Param}} items",
+	"max":              "{{.Field}} must be at most {{.Param}}",
+	"max.string":       "{{.Field}} must be at most {{.Param}} characters long",
+	"max.items":        "{{.Field}} must contain at most {{.Param}} items",
+	"lte":              "{{.Field}} must be at most {{.Param}}",
+	"lte.string":       "{{.Field}} must be at most {{.Param}} characters long",
+	"lte.items":        "{{.Field}} must contain at most {{.Param}} items",
+	"gt":               "{{.Field}} must be greater than {{.Param}}",
+	"lt":               "{{.Field}} must be less than {{.Param}}",
+}
+
+// translations of the messages, by language
+var translations = map[string]map[string]string{
+	"zh-CN": {
+		"{{.Field}} is invalid":                                  "{{.Field}}无效",
+		"{{.Field}} is required":                                 "{{.Field}}为必填字段",
+		"{{.Field}} must be a valid email address":               "{{.Field}}必须是有效的电子邮件地址",
+		"{{.Field}} must be a valid URL":                         "{{.Field}}必须是有效的URL",
+		"{{.Field}} must be a valid UUID":                        "{{.Field}}必须是有效的UUID",
+		"{{.Field}} must be a valid IP address":                  "{{.Field}}必须是有效的IP地址",
+		"{{.Field}} must be a number":                            "{{.Field}}必须是数字",
+		"{{.Field}} can only contain letters":                    "{{.Field}}只能包含字母",
+		"{{.Field}} can only contain letters and numbers":        "{{.Field}}只能包含字母和数字",
+		"{{.Field}} must be a boolean":                           "{{.Field}}必须是布尔值",
+		"{{.Field}} must be a date in the format {{.Param}}":     "{{.Field}}必须是格式为{{.Param}}的日期",
+		"{{.Field}} must be one of {{.Param}}":                   "{{.Field}}必须是[{{.Param}}]中的一个",
+		"{{.Field}} must contain {{.Param}}":                     "{{.Field}}必须包含{{.Param}}",
+		"{{.Field}} must be equal to {{.Param}}":                 "{{.Field}}必须等于{{.Param}}",
+		"{{.Field}} must be exactly {{.Param}} characters long":  "{{.Field}}长度必须为{{.Param}}个字符",
+		"{{.Field}} must contain exactly {{.Param}} items":       "{{.Field}}必须包含{{.Param}}项",
+		"{{.Field}} must not be equal to {{.Param}}":             "{{.Field}}不能等于{{.Param}}",
+		"{{.Field}} must be at least {{.Param}}":                 "{{.Field}}不能小于{{.Param}}",
+		"{{.Field}} must be at least {{.Param}} characters long": "{{.Field}}长度不能少于{{.Param}}个字符",
+		"{{.Field}} must contain at least {{.Param}} items":      "{{.Field}}至少包含{{.Param}}项",
+		"{{.Field}} must be at most {{.Param}}":                  "{{.Field}}不能大于{{.Param}}",
+		"{{.Field}} must be at most {{.Param}} characters long":  "{{.Field}}长度不能超过{{.Param}}个字符",
+		"{{.Field}} must contain at most {{.Param}} items":       "{{.Field}}最多包含{{.Param}}项",
+		"{{.Field}} must be greater than {{.Param}}":             "{{.Field}}必须大于{{.Param}}",
+		"{{.Field}} must be less than {{.Param}}":                "{{.Field}}必须小于{{.Param}}",
+	},
+	"ja": {
+		"{{.Field}} is invalid":                                  "{{.Field}}が無効です",
+		"{{.Field}} is required":                                 "{{.Field}}は必須です",
+		"{{.Field}} must be a valid email address":               "{{.Field}}は有効なメールアドレスである必要があります",
+		"{{.Field}} must be a valid URL":                         "{{.Field}}は有効なURLである必要があります",
+		"{{.Field}} must be a valid UUID":                        "{{.Field}}は有効なUUIDである必要があります",
+		"{{.Field}} must be a valid IP address":                  "{{.Field}}は有効なIPアドレスである必要があります",
+		"{{.Field}} must be a number":                            "{{.Field}}は数値である必要があります",
+		"{{.Field}} can only contain letters":                    "{{.Field}}には英字のみ使用できます",
+		"{{.Field}} can only contain letters and numbers":        "{{.Field}}には英数字のみ使用できます",
+		"{{.Field}} must be a boolean":                           "{{.Field}}は真偽値である必要があります",
+		"{{.Field}} must be a date in the format {{.Param}}":     "{{.Field}}は{{.Param}}形式の日付である必要があります",
+		"{{.Field}} must be one of {{.Param}}":                   "{{.Field}}は[{{.Param}}]のいずれかである必要があります",
+		"{{.Field}} must contain {{.Param}}":                     "{{.Field}}は{{.Param}}を含む必要があります",
+		"{{.Field}} must be equal to {{.Param}}":                 "{{.Field}}は{{.Param}}と等しい必要があります",
+		"{{.Field}} must be exactly {{.Param}} characters long":  "{{.Field}}は{{.Param}}文字である必要があります",
+		"{{.Field}} must contain exactly {{.Param}} items":       "{{.Field}}は{{.Param}}個の項目を含む必要があります",
+		"{{.Field}} must not be equal to {{.Param}}":             "{{.Field}}は{{.Param}}と異なる必要があります",
+		"{{.Field}} must be at least {{.Param}}":                 "{{.Field}}は{{.Param}}以上である必要があります",
+		"{{.Field}} must be at least {{.Param}} characters long": "{{.Field}}は{{.Param}}文字以上である必要があります",
+		"{{.Field}} must contain at least {{.Param}} items":      "{{.Field}}は{{.Param}}個以上の項目を含む必要があります",
+		"{{.Field}} must be at most {{.Param}}":                  "{{.Field}}は{{.Param}}以下である必要があります",
+		"{{.Field}} must be at most {{.Param}} characters long":  "{{.Field}}は{{.Param}}文字以下である必要があります",
+		"{{.Field}} must contain at most {{.Param}} items":       "{{.Field}}は{{.Param}}個以下の項目を含む必要があります",
+		"{{.Field}} must be greater than {{.Param}}":             "{{.Field}}は{{.Param}}より大きい必要があります",
+		"{{.Field}} must be less than {{.Param}}":                "{{.Field}}は{{.Param}}より小さい必要があります",
+	},
+	"de": {
+		"{{.Field}} is invalid":                                  "{{.Field}} ist ungültig",
+		"{{.Field}} is required":                                 "{{.Field}} ist erforderlich",
+		"{{.Field}} must be a valid email address":               "{{.Field}} muss eine gültige E-Mail-Adresse sein",
+		"{{.Field}} must be a valid URL":                         "{{.Field}} muss eine gültige URL sein",
+		"{{.Field}} must be a valid UUID":                        "{{.Field}} muss eine gültige UUID sein",
+		"{{.Field}} must be a valid IP address":                  "{{.Field}} muss eine gültige IP-Adresse sein",
+		"{{.Field}} must be a number":                            "{{.Field}} muss eine Zahl sein",
+		"{{.Field}} can only contain letters":                    "{{.Field}} darf nur Buchstaben enthalten",
+		"{{.Field}} can only contain letters and numbers":        "{{.Field}} darf nur Buchstaben und Zahlen enthalten",
+		"{{.Field}} must be a boolean":                           "{{.Field}} muss ein boolescher Wert sein",
+		"{{.Field}} must be a date in the format {{.Param}}":     "{{.Field}} muss ein Datum im Format {{.Param}} sein",
+		"{{.Field}} must be one of {{.Param}}":                   "{{.Field}} muss einer der folgenden Werte sein: {{.Param}}",
+		"{{.Field}} must contain {{.Param}}":                     "{{.Field}} muss {{.Param}} enthalten",
+		"{{.Field}} must be equal to {{.Param}}":                 "{{.Field}} muss gleich {{.Param}} sein",
+		"{{.Field}} must be exactly {{.Param}} characters long":  "{{.Field}} muss genau {{.Param}} Zeichen lang sein",
+		"{{.Field}} must contain exactly {{.Param}} items":       "{{.Field}} muss genau {{.Param}} Elemente enthalten",
+		"{{.Field}} must not be equal to {{.Param}}":             "{{.Field}} darf nicht gleich {{.Param}} sein",
+		"{{.Field}} must be at least {{.Param}}":                 "{{.Field}} muss mindestens {{.Param}} sein",
+		"{{.Field}} must be at least {{.Param}} characters long": "{{.Field}} muss mindestens {{.Param}} Zeichen lang sein",
+		"{{.Field}} must contain at least {{.Param}} items":      "{{.Field}} muss mindestens {{.Param}} Elemente enthalten",
+		"{{.Field}} must be at most {{.Param}}":                  "{{.Field}} darf höchstens {{.Param}} sein",
+		"{{.Field}} must be at most {{.Param}} characters long":  "{{.Field}} darf höchstens {{.Param}} Zeichen lang sein",
+		"{{.Field}} must contain at most {{.Param}} items":       "{{.Field}} darf höchstens {{.Param}} Elemente enthalten",
+		"{{.Field}} must be greater than {{.Param}}":             "{{.Field}} muss größer als {{.Param}} sein",
+		"{{.Field}} must be less than {{.Param}}":                "{{.Field}} muss kleiner als {{.Param}} sein",
+	},
+	"fr": {
+		"{{.Field}} is invalid":                                  "{{.Field}} n'est pas valide",
+		"{{.Field}} is required":                                 "{{.Field}} est obligatoire",
+		"{{.Field}} must be a valid email address":               "{{.Field}} doit être une adresse e-mail valide",
+		"{{.Field}} must be a valid URL":                         "{{.Field}} doit être une URL valide",
+		"{{.Field}} must be a valid UUID":                        "{{.Field}} doit être un UUID valide",
+		"{{.Field}} must be a valid IP address":                  "{{.Field}} doit être une adresse IP valide",
+		"{{.Field}} must be a number":                            "{{.Field}} doit être un nombre",
+		"{{.Field}} can only contain letters":                    "{{.Field}} ne peut contenir que des lettres",
+		"{{.Field}} can only contain letters and numbers":        "{{.Field}} ne peut contenir que des lettres et des chiffres",
+		"{{.Field}} must be a boolean":                           "{{.Field}} doit être un booléen",
+		"{{.Field}} must be a date in the format {{.Param}}":     "{{.Field}} doit être une date au format {{.Param}}",
+		"{{.Field}} must be one of {{.Param}}":                   "{{.Field}} doit être l'une des valeurs suivantes : {{.Param}}",
+		"{{.Field}} must contain {{.Param}}":                     "{{.Field}} doit contenir {{.Param}}",
+		"{{.Field}} must be equal to {{.Param}}":                 "{{.Field}} doit être égal à {{.Param}}",
+		"{{.Field}} must be exactly {{.Param}} characters long":  "{{.Field}} doit contenir exactement {{.Param}} caractères",
+		"{{.Field}} must contain exactly {{.Param}} items":       "{{.Field}} doit contenir exactement {{.Param}} éléments",
+		"{{.Field}} must not be equal to {{.Param}}":             "{{.Field}} ne doit pas être égal à {{.Param}}",
+		"{{.Field}} must be at least {{.Param}}":                 "{{.Field}} doit être supérieur ou égal à {{.Param}}",
+		"{{.Field}} must be at least {{.Param}} characters long": "{{.Field}} doit contenir au moins {{.Param}} caractères",
+		"{{.Field}} must contain at least {{.Param}} items":      "{{.Field}} doit contenir au moins {{.Param}} éléments",
+		"{{.Field}} must be at most {{.Param}}":                  "{{.Field}} doit être inférieur ou égal à {{.Param}}",
+		"{{.Field}} must be at most {{.Param}} characters long":  "{{.Field}} doit contenir au plus {{.Param}} caractères",
+		"{{.Field}} must contain at most {{.Param}} items":       "{{.Field}} doit contenir au plus {{.Param}} éléments",
+		"{{.Field}} must be greater than {{.Param}}":             "{{.Field}} doit être supérieur à {{.Param}}",
+		"{{.Field}} must be less than {{.Param}}":                "{{.Field}} doit être inférieur à {{.Param}}",
+	},
+	"es": {
+		"{{.Field}} is invalid":                                  "{{.Field}} no es válido",
+		"{{.Field}} is required":                                 "{{.Field}} es obligatorio",
+		"{{.Field}} must be a valid email address":               "{{.Field}} debe ser una dirección de correo electrónico válida",
+		"{{.Field}} must be a valid URL":                         "{{.Field}} debe ser una URL válida",
+		"{{.Field}} must be a valid UUID":                        "{{.Field}} debe ser un UUID válido",
+		"{{.Field}} must be a valid IP address":                  "{{.Field}} debe ser una dirección IP válida",
+		"{{.Field}} must be a number":                            "{{.Field}} debe ser un número",
+		"{{.Field}} can only contain letters":                    "{{.Field}} solo puede contener letras",
+		"{{.Field}} can only contain letters and numbers":        "{{.Field}} solo puede contener letras y números",
+		"{{.Field}} must be a boolean":                           "{{.Field}} debe ser un valor booleano",
+		"{{.Field}} must be a date in the format {{.Param}}":     "{{.Field}} debe ser una fecha con el formato {{.Param}}",
+		"{{.Field}} must be one of {{.Param}}":                   "{{.Field}} debe ser uno de los siguientes valores: {{.Param}}",
+		"{{.Field}} must contain {{.Param}}":                     "{{.Field}} debe contener {{.Param}}",
+		"{{.Field}} must be equal to {{.Param}}":                 "{{.Field}} debe ser igual a {{.Param}}",
+		"{{.Field}} must be exactly {{.Param}} characters long":  "{{.Field}} debe tener exactamente {{.Param}} caracteres",
+		"{{.Field}} must contain exactly {{.Param}} items":       "{{.Field}} debe contener exactamente {{.Param}} elementos",
+		"{{.Field}} must not be equal to {{.Param}}":             "{{.Field}} no debe ser igual a {{.Param}}",
+		"{{.Field}} must be at least {{.Param}}":                 "{{.Field}} debe ser como mínimo {{.Param}}",
+		"{{.Field}} must be at least {{.Param}} characters long": "{{.Field}} debe tener al menos {{.Param}} caracteres",
+		"{{.Field}} must contain at least {{.Param}} items":      "{{.Field}} debe contener al menos {{.Param}} elementos",
+		"{{.Field}} must be at most {{.Param}}":                  "{{.Field}} debe ser como máximo {{.Param}}",
+		"{{.Field}} must be at most {{.Param}} characters long":  "{{.Field}} debe tener como máximo {{.Param}} caracteres",
+		"{{.Field}} must contain at most {{.Param}} items":       "{{.Field}} debe contener como máximo {{.Param}} elementos",
+		"{{.Field}} must be greater than {{.Param}}":             "{{.Field}} debe ser mayor que {{.Param}}",
+		"{{.Field}} must be less than {{.Param}}":                "{{.Field}} debe ser menor que {{.Param}}",
+	},
+}
+
+// Catalog returns the translations of the messages of the standard validation tags, in the bundle BundleName,
+// for English, Simplified Chinese, Japanese, German, French and Spanish, or only the given languages.
+func Catalog(langs ...string) i18n.Catalog {
+	all := map[string]map[string]string{"en": {}}
+	for _, msg := range messages {
+		all["en"][msg] = msg
+	}
+	for lang, kv := range translations {
+		all[lang] = kv
+	}
+
+	catalog := map[string]map[string]string{}
+	for lang, kv := range all {
+		if len(langs) > 0 && !includes(langs, lang) {
+			continue
+		}
+
+		catalog[lang] = make(map[string]string, len(kv))
+		for key, value := range kv {
+			catalog[lang][key] = value
+		}
+	}
+
+	return i18n.Catalog{BundleName: catalog}
+}
+
+// RegisterCatalog registers the Catalog of the given languages, or all of them, for all the instances,
+// with a lower priority than their sources and locale files, see i18n.RegisterModule.
+// The catalog is not registered by default, so the languages of the applications are not changed.
+func RegisterCatalog(langs ...string) {
+	i18n.RegisterModule(Module, Catalog(langs...), BundleName)
+}
+
+// includes reports whether the language is one of langs, once normalised
+func includes(langs []string, lang string) bool {
+	tag, err := i18n.NormalizeLanguage(lang)
+	if err != nil {
+		return false
+	}
+
+	for _, l := range langs {
+		if t, err := i18n.NormalizeLanguage(l); err == nil && t == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,280 @@
+// Package i18nvalidator translates the validation errors of go-playground/validator,
+// e.g. those returned by the binding of Gin, into a types.Error holding a translated message per field.
+package i18nvalidator
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/epkgs/i18n"
+	"github.com/epkgs/i18n/errors"
+	"github.com/epkgs/i18n/types"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	// BundleName is the bundle of the messages of the validation tags
+	BundleName = "validator"
+
+	// ExtraFields is the key of the FieldErrors in the extra data of the translated errors
+	ExtraFields = errors.ExtraFields
+)
+
+// Config is the configuration of a Translator
+type Config struct {
+	// Bundle translates the messages of the validation tags, default: the bundle BundleName of the default instance
+	Bundle types.Bundler
+
+	// Fields translates the display names of the fields, default: nil, they are not translated
+	Fields types.Bundler
+
+	// LabelTag is the struct tag of the display name of a field, default: "label", the field name when unset
+	LabelTag string
+
+	// KeyTag is the struct tag of the name of a field in the FieldErrors, default: "json", the field name when unset
+	KeyTag string
+
+	// Messages override the messages of the validation tags, or add those of custom tags.
+	// They are keyed by tag, optionally followed by ".string" or ".items" for the strings and the collections,
+	// and are templates with the fields Field, Param, Value and Tag, e.g. {"mobile": "{{.Field}} must be a mobile number"}
+	Messages map[string]string
+
+	// HttpStatus is the HTTP status of the translated errors, default: http.StatusBadRequest
+	HttpStatus int
+}
+
+// Translator translates validation errors
+type Translator struct {
+	cfg *Config
+}
+
+// New returns a Translator configured with the config functions
+func New(config ...func(c *Config)) *Translator {
+	cfg := &Config{
+		LabelTag:   "label",
+		KeyTag:     "json",
+		HttpStatus: http.StatusBadRequest,
+	}
+	for _, fn := range config {
+		fn(cfg)
+	}
+
+	if cfg.Bundle == nil {
+		cfg.Bundle = i18n.Bundle(BundleName)
+	}
+
+	return &Translator{cfg: cfg}
+}
+
+var defaultTranslator = sync.OnceValue(func() *Translator {
+	return New()
+})
+
+// Translate translates the validation errors of err with the default Translator, see Translator.Error
+func Translate(err error, obj any) error {
+	return defaultTranslator().Error(err, obj)
+}
+
+// Error translates the validation errors of err, obj is the validated struct, or nil.
+// It returns a types.Error with the message of the first invalid field,
+// the HTTP status of the Translator, and the FieldErrors under ExtraFields, see Fields.
+// The names and display names of the fields are read from the struct tags of obj, when given.
+// The other errors, e.g. a malformed JSON body, are returned as is.
+//
+//	var req LoginRequest
+//	if err := c.ShouldBindJSON(&req); err != nil {
+//		return i18nvalidator.Translate(err, &req)
+//	}
+func (t *Translator) Error(err error, obj any) error {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) || len(validationErrs) == 0 {
+		return err
+	}
+
+	fields := FieldErrors{}
+	var first types.Stringer
+
+	for _, fe := range validationErrs {
+		key, label := t.field(fe, obj)
+
+		msg := &fieldMessage{
+			t:     t,
+			msg:   t.message(fe),
+			label: label,
+			fe:    fe,
+		}
+
+		// the first failed validation of each field
+		if _, ok := fields[key]; !ok {
+			fields[key] = msg
+		}
+		if first == nil {
+			first = msg
+		}
+	}
+
+	e := errors.New(first).Wrap(err)
+	e.Set(ExtraFields, fields)
+
+	return errors.WithHttpStatus(e, t.cfg.HttpStatus)
+}
+
+// message returns the message of the tag of the validation error
+func (t *Translator) message(fe validator.FieldError) string {
+	kind := ""
+	switch fe.Kind() {
+	case reflect.String:
+		kind = ".string"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		kind = ".items"
+	}
+
+	for _, m := range []map[string]string{t.cfg.Messages, messages} {
+		if msg, ok := m[fe.Tag()+kind]; ok {
+			return msg
+		}
+		if msg, ok := m[fe.Tag()]; ok {
+			return msg
+		}
+	}
+
+	return messages[""]
+}
+
+// field returns the name of the field of the validation error in the FieldErrors, e.g. "address.city",
+// and its display name
+func (t *Translator) field(fe validator.FieldError, obj any) (key, label string) {
+	// the namespace starts with the name of the validated struct
+	_, key, _ = strings.Cut(fe.Namespace(), ".")
+	label = fe.Field()
+
+	typ := reflect.TypeOf(obj)
+	if typ == nil {
+		return key, label
+	}
+
+	_, namespace, _ := strings.Cut(fe.StructNamespace(), ".")
+	names := []string{}
+
+	for _, part := range strings.Split(namespace, ".") {
+		// e.g. Items[0]
+		name, index, _ := strings.Cut(part, "[")
+		if index != "" {
+			index = "[" + index
+		}
+
+		for typ.Kind() == reflect.Pointer || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array || typ.Kind() == reflect.Map {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct {
+			return key, label
+		}
+
+		sf, ok := typ.FieldByName(name)
+		if !ok {
+			return key, label
+		}
+
+		fieldName := sf.Name
+		if tag, _, _ := strings.Cut(sf.Tag.Get(t.cfg.KeyTag), ","); tag != "" && tag != "-" {
+			fieldName = tag
+		}
+		names = append(names, fieldName+index)
+
+		label = sf.Name
+		if l := sf.Tag.Get(t.cfg.LabelTag); l != "" {
+			label = l
+		}
+
+		typ = sf.Type
+	}
+
+	return strings.Join(names, "."), label
+}
+
+// fieldMessage is the translatable message of a validation error
+type fieldMessage struct {
+	t     *Translator
+	msg   string
+	label string
+	fe    validator.FieldError
+}
+
+func (m *fieldMessage) args(label string) map[string]any {
+	return map[string]any{
+		"Field": label,
+		"Param": m.fe.Param(),
+		"Value": m.fe.Value(),
+		"Tag":   m.fe.Tag(),
+	}
+}
+
+// String returns the untranslated message
+func (m *fieldMessage) String() string {
+	return m.t.cfg.Bundle.Str(m.msg, m.args(m.label)).String()
+}
+
+// T returns the message translated in the language of the context, with the translated display name of the field
+func (m *fieldMessage) T(ctx context.Context) string {
+	label := m.label
+	if m.t.cfg.Fields != nil {
+		label = m.t.cfg.Fields.Str(label).T(ctx)
+	}
+	return m.t.cfg.Bundle.Str(m.msg, m.args(label)).T(ctx)
+}
+
+// TL returns the message translated in the languages, with the translated display name of the field
+func (m *fieldMessage) TL(langs ...string) string {
+	label := m.label
+	if m.t.cfg.Fields != nil {
+		label = m.t.cfg.Fields.Str(label).TL(langs...)
+	}
+	return m.t.cfg.Bundle.Str(m.msg, m.args(label)).TL(langs...)
+}
+
+// FieldErrors are the translatable messages of the invalid fields, by field name
+type FieldErrors map[string]types.Stringer
+
+// T returns the messages translated in the language of the context
+func (f FieldErrors) T(ctx context.Context) map[string]string {
+	msgs := make(map[string]string, len(f))
+	for field, msg := range f {
+		msgs[field] = msg.T(ctx)
+	}
+	return msgs
+}
+
+// TL returns the messages translated in the languages
+func (f FieldErrors) TL(langs ...string) map[string]string {
+	msgs := make(map[string]string, len(f))
+	for field, msg := range f {
+		msgs[field] = msg.TL(langs...)
+	}
+	return msgs
+}
+
+// MarshalJSON encodes the untranslated messages, use T to encode the translated ones
+func (f FieldErrors) MarshalJSON() ([]byte, error) {
+	msgs := make(map[string]string, len(f))
+	for field, msg := range f {
+		msgs[field] = msg.String()
+	}
+	return json.Marshal(msgs)
+}
+
+// Fields returns the messages of the invalid fields of the error returned by Translator.Error,
+// translated in the language of the context, or nil if the error chain holds none
+func Fields(ctx context.Context, err error) map[string]string {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if s, ok := err.(types.Storager); ok {
+			if fields, ok := s.Get(ExtraFields, nil).(FieldErrors); ok {
+				return fields.T(ctx)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package i18nvalidator
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/epkgs/i18n"
+	"github.com/epkgs/i18n/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+type address struct {
+	City string `json:"city" label:"City" validate:"required"`
+}
+
+type signupRequest struct {
+	Username string   `json:"username" label:"Username" validate:"required"`
+	Password string   `json:"password" validate:"min=8"`
+	Tags     []string `json:"tags" validate:"max=2"`
+	Address  address  `json:"address"`
+}
+
+func TestTranslatorError(t *testing.T) {
+	RegisterCatalog("en", "zh-CN")
+
+	n, err := i18n.NewKV(map[string]map[string]string{
+		"zh-CN": {"Username": "用户名"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tr := New(func(c *Config) {
+		c.Bundle = n.Bundle(BundleName)
+		c.Fields = n.Bundle("fields")
+	})
+
+	req := signupRequest{Password: "secret", Tags: []string{"a", "b", "c"}}
+	err = tr.Error(validator.New().Struct(&req), &req)
+
+	if status := errors.HttpStatus(err); status != http.StatusBadRequest {
+		t.Errorf("http status = %d, want %d", status, http.StatusBadRequest)
+	}
+
+	// 字段名取自 json 标签，显示名取自 label 标签并通过 Fields 翻译
+	ctx := i18n.WithAcceptLanguages(context.Background(), "zh-CN")
+	want := map[string]string{
+		"username":     "用户名为必填字段",
+		"password":     "Password长度不能少于8个字符",
+		"tags":         "Tags最多包含2项",
+		"address.city": "City为必填字段",
+	}
+	got := Fields(ctx, err)
+	if len(got) != len(want) {
+		t.Fatalf("fields = %v, want %v", got, want)
+	}
+	for field, msg := range want {
+		if got[field] != msg {
+			t.Errorf("fields[%q] = %q, want %q", field, got[field], msg)
+		}
+	}
+
+	// 错误消息为第一个字段的消息
+	if msg := err.(interface{ TL(...string) string }).TL("en"); msg != "Username is required" {
+		t.Errorf("message = %q, want %q", msg, "Username is required")
+	}
+}
+
+func TestTranslatorErrorWithoutStruct(t *testing.T) {
+	tr := New(func(c *Config) {
+		c.Messages = map[string]string{"required": "{{.Field}} is missing"}
+	})
+
+	err := tr.Error(validator.New().Struct(&address{}), nil)
+
+	got := Fields(context.Background(), err)
+	if got["City"] != "City is missing" {
+		t.Errorf("fields = %v, want City is missing", got)
+	}
+
+	// 非校验错误原样返回
+	if err := tr.Error(io.ErrUnexpectedEOF, nil); err != io.ErrUnexpectedEOF {
+		t.Errorf("error = %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
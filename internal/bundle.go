@@ -2,6 +2,7 @@ package internal
 
 import (
 	"context"
+	"sort"
 	"sync"
 
 	"github.com/epkgs/i18n/errors"
@@ -11,26 +12,89 @@ import (
 
 // i18nBundle represents an internationalization bundle containing translations for different languages
 type i18nBundle struct {
-	Name  string
-	trans map[language.Tag]map[string]string // language identifier -> default text -> translated text
+	Name string
 
-	matcher  *Matcher
-	load     Loader
-	loadOnce *sync.Once
+	mu      sync.RWMutex
+	loaded  bool
+	trans   map[language.Tag]map[string]string       // language identifier -> default text -> translated text
+	overlay map[language.Tag]map[string]overlayEntry // runtime changes applied on top of the loaded translations
+	defined map[language.Tag]map[string]string       // translations defined in code, below the loaded translations
+	parents []*i18nBundle                            // bundles in which missing keys are resolved
+
+	matcher *Matcher
+	load    Loader
+}
+
+// overlayEntry is a runtime change of a translation
+type overlayEntry struct {
+	value   string
+	deleted bool
 }
 
 func NewBundle(name string, matcher *Matcher, loader Loader) types.Bundler {
 	b := &i18nBundle{
-		Name:     name,
-		trans:    map[language.Tag]map[string]string{},
-		matcher:  matcher,
-		load:     loader,
-		loadOnce: &sync.Once{},
+		Name:    name,
+		trans:   map[language.Tag]map[string]string{},
+		overlay: map[language.Tag]map[string]overlayEntry{},
+		defined: map[language.Tag]map[string]string{},
+		matcher: matcher,
+		load:    loader,
 	}
 
 	return b
 }
 
+// SetParents sets the bundles in which the keys missing in the bundle are resolved,
+// in order, before falling back to the default language.
+func SetParents(bundle types.Bundler, parents ...types.Bundler) {
+	b, ok := bundle.(*i18nBundle)
+	if !ok {
+		return
+	}
+
+	ps := make([]*i18nBundle, 0, len(parents))
+	for _, parent := range parents {
+		if p, ok := parent.(*i18nBundle); ok && p != b {
+			ps = append(ps, p)
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.parents = ps
+}
+
+// Rebind moves the bundle to another matcher and loader, e.g. when the instance it belongs to is replaced.
+// The translations are loaded again on next use, the overlay, the defined messages and the parents are kept,
+// and the languages of the defined messages and of the overlay are added to the matcher.
+func Rebind(bundle types.Bundler, matcher *Matcher, loader Loader) {
+	b, ok := bundle.(*i18nBundle)
+	if !ok {
+		return
+	}
+
+	b.mu.Lock()
+
+	b.matcher = matcher
+	b.load = loader
+	b.loaded = false
+
+	defined := make([]language.Tag, 0, len(b.defined))
+	for lang := range b.defined {
+		defined = append(defined, lang)
+	}
+	overlay := make([]language.Tag, 0, len(b.overlay))
+	for lang := range b.overlay {
+		overlay = append(overlay, lang)
+	}
+
+	b.mu.Unlock()
+
+	matcher.Add(defined...)
+	matcher.Insert(overlay...)
+}
+
 // Str creates and returns a new Stringer object for handling internationalized strings
 //   - txt: the original text to be translated
 //   - args: arguments used to replace placeholders in the text
@@ -40,6 +104,15 @@ func (b *i18nBundle) Str(txt string, args ...any) types.Stringer {
 	return NewString(b, txt, args...)
 }
 
+// HTML creates and returns a new HTMLStringer object for handling internationalized HTML markup
+//   - txt: the original HTML text to be translated
+//   - args: arguments used to replace placeholders in the text, they are always HTML escaped
+//
+// Returns a HTMLStringer interface whose translations are sanitized against an allowlist of tags
+func (b *i18nBundle) HTML(txt string, args ...any) types.HTMLStringer {
+	return NewHTMLString(b, txt, args...)
+}
+
 // NStr selects singular or plural form of string based on quantity and formats it
 //   - n: quantity value to determine singular/plural form. Accepts numeric types (int, float, etc.) and boolean.
 //     For numeric types: singular form is used when value equals 1
@@ -52,22 +125,68 @@ func (b *i18nBundle) Str(txt string, args ...any) types.Stringer {
 // Returns: internationalized Stringer interface based on quantity
 func (b *i18nBundle) NStr(n any, one, others string, args ...any) types.Stringer {
 
-	var isOne bool
-	switch t := n.(type) {
-	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr, float32, float64:
-		isOne = t == 1
-	case bool:
-		isOne = t
-	default:
-		isOne = false
-	}
-
-	if isOne {
+	if IsOne(n) {
 		return b.Str(one, args...)
 	}
 	return b.Str(others, args...)
 }
 
+// Define declares the message of the key with its built-in translations, by language code,
+// and returns it. The translations have the lowest priority: sources and locale files override them.
+//   - key: the message key
+//   - text: the built-in translations, e.g. types.Text{"en": "Not found", "zh-CN": "未找到"}
+//
+// Returns a Stringer interface whose untranslated text is the text of the default language
+func (b *i18nBundle) Define(key string, text types.Text) types.Stringer {
+	tags := make([]language.Tag, 0, len(text))
+
+	b.mu.Lock()
+	for lang, txt := range text {
+		tag := ParseLanguageTag(lang)
+		if tag == language.Und {
+			continue
+		}
+		if b.defined[tag] == nil {
+			b.defined[tag] = map[string]string{}
+		}
+		b.defined[tag][key] = txt
+		tags = append(tags, tag)
+	}
+	b.loaded = false
+	b.mu.Unlock()
+
+	b.matcher.Add(tags...)
+
+	return b.Str(key)
+}
+
+// isDefined reports whether the key was declared by Define
+func (b *i18nBundle) isDefined(key string) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, kv := range b.defined {
+		if _, ok := kv[key]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasDefined reports whether messages were declared in the bundle by Define
+func HasDefined(bundle types.Bundler) bool {
+	b, ok := bundle.(*i18nBundle)
+	if !ok {
+		return false
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return len(b.defined) > 0
+}
+
 // Err creates and returns an internationalizable error object
 //   - txt: the original error text to be translated
 //   - args: arguments used to replace placeholders in the text
@@ -92,25 +211,137 @@ func (b *i18nBundle) NErr(n any, one, others string, args ...any) types.Error {
 }
 
 func (b *i18nBundle) SetDefaultLanguage(t language.Tag) bool {
+	b.matcher.SetDefaultLanguage(t)
+	return true
+}
 
-	languages := b.matcher.Languages()
-	idx := IndexOf(languages, t)
+// Languages returns the languages which have translations loaded, the default language first
+func (b *i18nBundle) Languages() []language.Tag {
+	b.rlock()
+	defer b.mu.RUnlock()
 
-	if idx == 0 {
-		return true
+	langs := []language.Tag{}
+	for _, lang := range b.matcher.Languages() {
+		if _, ok := b.trans[lang]; ok {
+			langs = append(langs, lang)
+		}
 	}
 
-	if idx == -1 {
-		languages = append([]language.Tag{t}, languages...)
-	} else {
-		old := languages[0]
-		languages[0] = t
-		languages[idx] = old
+	return langs
+}
+
+// Keys returns the sorted translation keys of the language, without those of the parents
+func (b *i18nBundle) Keys(lang language.Tag) []string {
+	b.rlock()
+	defer b.mu.RUnlock()
+
+	keys := make([]string, 0, len(b.trans[lang]))
+	for key := range b.trans[lang] {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
+
+	return keys
+}
 
-	b.matcher.SetLanguages(languages)
+// Lookup returns the translation of the key in the language, without any fallback
+func (b *i18nBundle) Lookup(lang language.Tag, key string) (string, bool) {
+	b.rlock()
+	defer b.mu.RUnlock()
 
-	return true
+	txt, ok := b.trans[lang][key]
+	return txt, ok
+}
+
+// Coverage returns the ratio of the keys of the default language which are translated in the language,
+// by the bundle or one of its parents, as they are resolved when translating
+func (b *i18nBundle) Coverage(lang language.Tag) float64 {
+	b.rlock()
+	defaults := b.trans[b.matcher.DefaultLanguage()]
+	total := len(defaults)
+	missing := []string{}
+	for key := range defaults {
+		if txt, ok := b.trans[lang][key]; !ok || txt == "" {
+			missing = append(missing, key)
+		}
+	}
+	b.mu.RUnlock()
+
+	if total == 0 {
+		return 1
+	}
+
+	parents := b.chain()[1:]
+	translated := total - len(missing)
+	for _, key := range missing {
+		for _, p := range parents {
+			if txt, ok := p.Lookup(lang, key); ok && txt != "" {
+				translated++
+				break
+			}
+		}
+	}
+
+	return float64(translated) / float64(total)
+}
+
+// Set adds or overrides the translation of the key in the language.
+// The change is kept in an overlay which survives Reload, until ClearOverlay is called.
+func (b *i18nBundle) Set(lang language.Tag, key, value string) {
+	b.Merge(lang, map[string]string{key: value})
+}
+
+// Merge adds or overrides the translations of the keys in the language atomically.
+// The changes are kept in an overlay which survives Reload, until ClearOverlay is called.
+// The translations are stored under the language as is, e.g. en-GB does not change en.
+func (b *i18nBundle) Merge(lang language.Tag, keyValues map[string]string) {
+	b.matcher.Insert(lang)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.ensureLoaded()
+
+	if b.overlay[lang] == nil {
+		b.overlay[lang] = map[string]overlayEntry{}
+	}
+	if b.trans[lang] == nil {
+		b.trans[lang] = map[string]string{}
+	}
+
+	for key, value := range keyValues {
+		b.overlay[lang][key] = overlayEntry{value: value}
+		b.trans[lang][key] = value
+	}
+}
+
+// Delete removes the translations of the keys in the language.
+// The removal is kept in an overlay which survives Reload, until ClearOverlay is called.
+func (b *i18nBundle) Delete(lang language.Tag, keys ...string) {
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.ensureLoaded()
+
+	if b.overlay[lang] == nil {
+		b.overlay[lang] = map[string]overlayEntry{}
+	}
+
+	for _, key := range keys {
+		b.overlay[lang][key] = overlayEntry{deleted: true}
+		delete(b.trans[lang], key)
+	}
+}
+
+// ClearOverlay drops the changes made by Set, Merge and Delete,
+// the translations are loaded again on next use.
+func (b *i18nBundle) ClearOverlay() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.overlay = map[language.Tag]map[string]overlayEntry{}
+	b.loaded = false
 }
 
 func (b *i18nBundle) transCtx(ctx context.Context, format string, args ...any) string {
@@ -118,8 +349,23 @@ func (b *i18nBundle) transCtx(ctx context.Context, format string, args ...any) s
 	return b.transLangs(langs, format, args...)
 }
 
-// translate translates the given format string based on language preferences in the context
+// transLangs translates the given format string based on the given language preferences
 func (b *i18nBundle) transLangs(langs []string, format string, args ...any) string {
+	return Parse(b.lookupLangs(langs, format), args...)
+}
+
+// transHTMLLangs translates the given HTML format string based on the given language preferences
+func (b *i18nBundle) transHTMLLangs(langs []string, format string, args ...any) string {
+	return ParseHTML(b.lookupLangs(langs, format), args...)
+}
+
+// lookupLangs retrieves the translated text of the key based on the given language preferences
+func (b *i18nBundle) lookupLangs(langs []string, key string) string {
+	return b.getTranslation(b.matchLangs(langs), key)
+}
+
+// matchLangs returns the language of the bundle the texts are translated in for the given language preferences
+func (b *i18nBundle) matchLangs(langs []string) language.Tag {
 
 	// Initialize a slice to store parsed language tags
 	tags := []language.Tag{}
@@ -131,40 +377,128 @@ func (b *i18nBundle) transLangs(langs []string, format string, args ...any) stri
 		}
 	}
 
-	translated := b.getTranslation(tags, format)
+	return b.matcher.Match(tags...)
+}
+
+// getTranslation retrieves the translated text of the key in the matched language
+func (b *i18nBundle) getTranslation(lang language.Tag, key string) string {
+
+	if IsPseudo(lang) {
+		// the translations loaded for the pseudo locale, e.g. by i18ncli extract, are used as is
+		for _, c := range b.chain() {
+			if txt, exist := c.Lookup(lang, key); exist {
+				return txt
+			}
+		}
+		// pseudo localize the text of the default language
+		return Pseudo(lang, b.translation(b.matcher.DefaultLanguage(), key))
+	}
+
+	return b.translation(lang, key)
+}
+
+// translation retrieves the translated text of the key in the language.
+// The key is resolved in the bundle and its parents, first in the language and then in the default language,
+// falling back to the key itself.
+func (b *i18nBundle) translation(lang language.Tag, key string) string {
+	chain := b.chain()
 
-	return Parse(translated, args...)
+	langs := []language.Tag{lang}
+	if defaultLanguage := b.matcher.DefaultLanguage(); defaultLanguage != lang {
+		langs = append(langs, defaultLanguage)
+	}
+
+	for _, l := range langs {
+		for _, c := range chain {
+			if txt, exist := c.Lookup(l, key); exist {
+				return txt
+			}
+		}
+	}
+
+	return key
 }
 
-// getTranslation retrieves the translated text for the given original text based on language tags
-func (b *i18nBundle) getTranslation(tags []language.Tag, key string) string {
+// chain returns the bundle followed by its ancestors, depth first, each bundle only once
+func (b *i18nBundle) chain() []*i18nBundle {
+	chain := []*i18nBundle{}
 
-	b.lazyLoad()
+	var walk func(c *i18nBundle)
+	walk = func(c *i18nBundle) {
+		if Includes(chain, c) {
+			return
+		}
+		chain = append(chain, c)
 
-	lang := b.matcher.Match(tags...)
+		c.mu.RLock()
+		parents := c.parents
+		c.mu.RUnlock()
 
-	trans, exist := b.trans[lang]
-	if !exist {
-		defaultLanguage := b.matcher.DefaultLanguage()
-		trans, exist = b.trans[defaultLanguage]
-		if !exist {
-			return key
+		for _, p := range parents {
+			walk(p)
 		}
 	}
+	walk(b)
+
+	return chain
+}
 
-	if txt, exist := trans[key]; exist {
-		return txt
+// rlock acquires the read lock, loading the translations first if needed
+func (b *i18nBundle) rlock() {
+	b.mu.RLock()
+	if b.loaded {
+		return
 	}
+	b.mu.RUnlock()
 
-	return key
+	b.mu.Lock()
+	b.ensureLoaded()
+	b.mu.Unlock()
+
+	b.mu.RLock()
 }
 
-func (b *i18nBundle) lazyLoad() {
-	b.loadOnce.Do(func() {
-		b.trans = b.load(b.Name, b.matcher)
-	})
+// ensureLoaded loads the translations and applies the overlay on top of them if needed,
+// the write lock must be held
+func (b *i18nBundle) ensureLoaded() {
+	if b.loaded {
+		return
+	}
+
+	// copy the defined and loaded translations, they are changed in place by the overlay
+	trans := map[language.Tag]map[string]string{}
+	for _, source := range []map[language.Tag]map[string]string{b.defined, b.load(b.Name, b.matcher)} {
+		for lang, kv := range source {
+			if trans[lang] == nil {
+				trans[lang] = make(map[string]string, len(kv))
+			}
+			for key, value := range kv {
+				trans[lang][key] = value
+			}
+		}
+	}
+
+	for lang, entries := range b.overlay {
+		if trans[lang] == nil {
+			trans[lang] = map[string]string{}
+		}
+		for key, entry := range entries {
+			if entry.deleted {
+				delete(trans[lang], key)
+			} else {
+				trans[lang][key] = entry.value
+			}
+		}
+	}
+
+	b.trans = trans
+	b.loaded = true
 }
 
+// Reload marks the translations to be loaded again on next use, the overlay is kept
 func (b *i18nBundle) Reload() {
-	b.loadOnce = &sync.Once{} // reset loadOnce
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.loaded = false
 }
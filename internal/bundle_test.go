@@ -0,0 +1,226 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+// staticLoader returns a loader of the translations, by language
+func staticLoader(trans map[string]map[string]string) Loader {
+	return func(bundleName string, m *Matcher) map[language.Tag]map[string]string {
+		loaded := map[language.Tag]map[string]string{}
+		for lang, kv := range trans {
+			tag := m.MatchOrAdd(language.MustParse(lang))
+			loaded[tag] = map[string]string{}
+			for key, value := range kv {
+				loaded[tag][key] = value
+			}
+		}
+		return loaded
+	}
+}
+
+func newPseudoBundle(defaultLanguage language.Tag) *i18nBundle {
+	m := NewMatcher(defaultLanguage)
+	m.SetPseudo(true)
+
+	return NewBundle("test", m, staticLoader(map[string]map[string]string{
+		"en": {"Hello": "Hello"},
+	})).(*i18nBundle)
+}
+
+func TestPseudoWithoutLanguages(t *testing.T) {
+	b := newPseudoBundle(PseudoAccents)
+
+	// 上下文中没有语言时使用伪本地化的默认语言
+	want := Pseudo(PseudoAccents, "Hello")
+	if got := b.Str("Hello").T(context.Background()); got != want {
+		t.Errorf("T() = %q, want %q", got, want)
+	}
+}
+
+func TestPseudoNotFirst(t *testing.T) {
+	b := newPseudoBundle(language.English)
+
+	tests := []struct {
+		langs []string
+		want  string
+	}{
+		{[]string{"ar-XB"}, Pseudo(PseudoBidi, "Hello")},
+		{[]string{"fr", "ar-XB"}, Pseudo(PseudoBidi, "Hello")},
+		{[]string{"fr", "en-XA", "ar-XB"}, Pseudo(PseudoAccents, "Hello")},
+		{[]string{"en", "ar-XB"}, "Hello"},
+	}
+
+	for _, tt := range tests {
+		if got := b.Str("Hello").TL(tt.langs...); got != tt.want {
+			t.Errorf("TL(%v) = %q, want %q", tt.langs, got, tt.want)
+		}
+	}
+}
+
+func newOverlayBundle() *i18nBundle {
+	return NewBundle("test", NewMatcher(language.English), staticLoader(map[string]map[string]string{
+		"en":    {"Color": "Color", "Hello": "Hello"},
+		"zh-CN": {"Color": "颜色", "Hello": "你好"},
+	})).(*i18nBundle)
+}
+
+func TestOverlaySet(t *testing.T) {
+	b := newOverlayBundle()
+	enGB := language.BritishEnglish
+
+	// 地区语言单独保存，不覆盖基础语言
+	b.Set(enGB, "Color", "Colour")
+
+	if got := b.Str("Color").TL("en"); got != "Color" {
+		t.Errorf("TL(en) = %q, want %q", got, "Color")
+	}
+	if got := b.Str("Color").TL("en-GB"); got != "Colour" {
+		t.Errorf("TL(en-GB) = %q, want %q", got, "Colour")
+	}
+	if got, ok := b.Lookup(enGB, "Color"); !ok || got != "Colour" {
+		t.Errorf("Lookup(en-GB) = %q, %v, want %q, true", got, ok, "Colour")
+	}
+	if _, ok := b.Lookup(language.English, "Colour"); ok {
+		t.Error("Lookup(en, Colour) found a translation")
+	}
+
+	// 覆盖已有翻译，重新加载后保留
+	b.Merge(language.English, map[string]string{"Hello": "Hi"})
+	b.Reload()
+	if got := b.Str("Hello").TL("en"); got != "Hi" {
+		t.Errorf("TL(en) after Reload = %q, want %q", got, "Hi")
+	}
+
+	b.ClearOverlay()
+	if got := b.Str("Hello").TL("en"); got != "Hello" {
+		t.Errorf("TL(en) after ClearOverlay = %q, want %q", got, "Hello")
+	}
+	if _, ok := b.Lookup(enGB, "Color"); ok {
+		t.Error("Lookup(en-GB) found a translation after ClearOverlay")
+	}
+}
+
+func TestOverlayDelete(t *testing.T) {
+	b := newOverlayBundle()
+	zh := language.MustParse("zh-CN")
+
+	b.Delete(zh, "Hello")
+
+	if _, ok := b.Lookup(zh, "Hello"); ok {
+		t.Error("Lookup(zh-CN) found a deleted translation")
+	}
+	// 删除后回退到默认语言
+	if got := b.Str("Hello").TL("zh-CN"); got != "Hello" {
+		t.Errorf("TL(zh-CN) = %q, want %q", got, "Hello")
+	}
+
+	b.Reload()
+	if _, ok := b.Lookup(zh, "Hello"); ok {
+		t.Error("Lookup(zh-CN) found a deleted translation after Reload")
+	}
+	if got, _ := b.Lookup(zh, "Color"); got != "颜色" {
+		t.Errorf("Lookup(zh-CN, Color) = %q, want %q", got, "颜色")
+	}
+}
+
+func TestOverlayConcurrency(t *testing.T) {
+	b := newOverlayBundle()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				key := fmt.Sprintf("key %d", j)
+				b.Set(language.English, key, key)
+				b.Str("Hello").TL("zh-CN")
+				b.Lookup(language.English, key)
+				if j%10 == 0 {
+					b.Delete(language.English, key)
+					b.Reload()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got, ok := b.Lookup(language.English, "key 99"); !ok || got != "key 99" {
+		t.Errorf("Lookup(key 99) = %q, %v, want %q, true", got, ok, "key 99")
+	}
+}
+
+func TestIntrospection(t *testing.T) {
+	m := NewMatcher(language.English)
+	zh, ja := language.MustParse("zh-CN"), language.Japanese
+
+	common := NewBundle("common", m, staticLoader(map[string]map[string]string{
+		"en": {"Save": "Save"},
+		"ja": {"Save": "保存する", "Cancel": "キャンセル"},
+	}))
+	b := NewBundle("user", m, staticLoader(map[string]map[string]string{
+		"en":    {"Save": "Save", "Cancel": "Cancel", "Hello": "Hello", "Bye": "Bye"},
+		"zh-CN": {"Save": "保存", "Cancel": "取消", "Hello": ""},
+		"ja":    {"Hello": "こんにちは"},
+	}))
+	SetParents(b, common)
+
+	// 默认语言在前，其他语言按加载的顺序
+	if got := b.Languages(); len(got) != 3 || got[0] != language.English || !Includes(got, zh) || !Includes(got, ja) {
+		t.Errorf("Languages() = %v, want en first, zh-CN and ja", got)
+	}
+	// 不包含父 bundle 的键
+	if got := b.Keys(ja); !reflect.DeepEqual(got, []string{"Hello"}) {
+		t.Errorf("Keys(ja) = %v, want [Hello]", got)
+	}
+	if _, ok := b.Lookup(ja, "Save"); ok {
+		t.Error("Lookup(ja, Save) found the translation of the parent")
+	}
+
+	tests := []struct {
+		lang language.Tag
+		want float64
+	}{
+		{language.English, 1},
+		// 空翻译和缺少的键不计入
+		{zh, 0.5},
+		// 计入父 bundle 的翻译
+		{ja, 0.75},
+		{language.French, 0},
+	}
+	for _, tt := range tests {
+		if got := b.Coverage(tt.lang); got != tt.want {
+			t.Errorf("Coverage(%s) = %v, want %v", tt.lang, got, tt.want)
+		}
+	}
+
+	// 只在覆盖层中的键
+	b.Set(zh, "Extra", "额外")
+	if got := b.Coverage(zh); got != 0.5 {
+		t.Errorf("Coverage(zh-CN) with a key missing in en = %v, want 0.5", got)
+	}
+	b.Set(zh, "Bye", "再见")
+	if got := b.Coverage(zh); got != 0.75 {
+		t.Errorf("Coverage(zh-CN) with an overlay translation = %v, want 0.75", got)
+	}
+	b.Set(language.English, "New", "New")
+	if got := b.Coverage(zh); got != 0.6 {
+		t.Errorf("Coverage(zh-CN) with an overlay key in en = %v, want 0.6", got)
+	}
+	if got := b.Keys(zh); !reflect.DeepEqual(got, []string{"Bye", "Cancel", "Extra", "Hello", "Save"}) {
+		t.Errorf("Keys(zh-CN) = %v", got)
+	}
+
+	// 默认语言没有键时覆盖率为 1
+	empty := NewBundle("empty", NewMatcher(language.English), staticLoader(nil))
+	if got := empty.Coverage(zh); got != 1 {
+		t.Errorf("Coverage of an empty bundle = %v, want 1", got)
+	}
+}
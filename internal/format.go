@@ -0,0 +1,113 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// dateLayouts holds the default date layout of a base language
+var dateLayouts = map[string]string{
+	"en": "Jan 2, 2006",
+	"zh": "2006年1月2日",
+	"ja": "2006年1月2日",
+	"ko": "2006년 1월 2일",
+	"de": "02.01.2006",
+	"ru": "02.01.2006",
+	"fr": "02/01/2006",
+	"es": "02/01/2006",
+	"it": "02/01/2006",
+	"pt": "02/01/2006",
+}
+
+// listPattern describes how the items of a list are joined in a base language
+type listPattern struct {
+	sep  string // separator between items
+	last string // separator before the last item
+	two  string // separator of a list with exactly two items
+}
+
+var listPatterns = map[string]listPattern{
+	"en": {sep: ", ", last: ", and ", two: " and "},
+	"zh": {sep: "、", last: "和", two: "和"},
+	"ja": {sep: "、", last: "、", two: "、"},
+	"de": {sep: ", ", last: " und ", two: " und "},
+	"fr": {sep: ", ", last: " et ", two: " et "},
+	"es": {sep: ", ", last: " y ", two: " y "},
+	"it": {sep: ", ", last: " e ", two: " e "},
+	"pt": {sep: ", ", last: " e ", two: " e "},
+	"ru": {sep: ", ", last: " и ", two: " и "},
+}
+
+// rtlScripts contains the scripts written from right to left
+var rtlScripts = []string{"Arab", "Hebr", "Syrc", "Thaa", "Nkoo", "Adlm", "Rohg", "Mand", "Samr"}
+
+// FormatNumber formats a numeric value with the digits and separators of the given language.
+// Non numeric values are formatted with fmt.Sprint.
+func FormatNumber(tag language.Tag, v any) string {
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return message.NewPrinter(tag).Sprint(number.Decimal(v))
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// FormatDate formats t with the given layout, or with the default layout of the language when layout is empty
+func FormatDate(tag language.Tag, t time.Time, layout string) string {
+	if layout == "" {
+		base, _ := tag.Base()
+		if layout = dateLayouts[base.String()]; layout == "" {
+			layout = time.DateOnly
+		}
+	}
+
+	return t.Format(layout)
+}
+
+// FormatList joins the items into a list using the conjunction of the given language
+func FormatList(tag language.Tag, items []string) string {
+	base, _ := tag.Base()
+	p, ok := listPatterns[base.String()]
+	if !ok {
+		p = listPattern{sep: ", ", last: ", ", two: ", "}
+	}
+
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	case 2:
+		return items[0] + p.two + items[1]
+	default:
+		return strings.Join(items[:len(items)-1], p.sep) + p.last + items[len(items)-1]
+	}
+}
+
+// IsRTL reports whether the language is written from right to left
+func IsRTL(tag language.Tag) bool {
+	script, _ := tag.Script()
+	return Includes(rtlScripts, script.String())
+}
+
+// IsOne reports whether n selects the singular form.
+// For numeric types it is true when the value equals 1, for boolean when the value is true.
+// Other types always select the plural form.
+func IsOne(n any) bool {
+	switch t := n.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr, float32, float64:
+		return t == 1
+	case bool:
+		return t
+	default:
+		return false
+	}
+}
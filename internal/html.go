@@ -0,0 +1,220 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"reflect"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// htmlAllowlist contains the tags allowed in HTML translations and their allowed attributes
+var htmlAllowlist = map[string][]string{
+	"a":      {"href", "target", "rel"},
+	"abbr":   {},
+	"b":      {},
+	"br":     {},
+	"code":   {},
+	"em":     {},
+	"i":      {},
+	"li":     {},
+	"mark":   {},
+	"ol":     {},
+	"p":      {},
+	"s":      {},
+	"small":  {},
+	"span":   {},
+	"strong": {},
+	"sub":    {},
+	"sup":    {},
+	"u":      {},
+	"ul":     {},
+}
+
+// htmlGlobalAttrs contains the attributes allowed on every allowed tag
+var htmlGlobalAttrs = []string{"title", "class", "lang", "dir"}
+
+// htmlDropContent contains the tags which are removed together with their content
+var htmlDropContent = []string{"script", "style", "iframe", "object", "embed", "template", "noscript"}
+
+// htmlSafeSchemes contains the URL schemes allowed in href attributes, relative URLs are always allowed
+var htmlSafeSchemes = []string{"http", "https", "mailto", "tel"}
+
+var (
+	textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	attrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&#34;")
+)
+
+// SanitizeHTML removes the tags and attributes not in the allowlist from s.
+// Disallowed tags are dropped but their text is kept, except for tags like script and style
+// which are dropped together with their content.
+// The allowed tags are balanced: stray end tags are dropped and the tags left open are closed.
+func SanitizeHTML(s string) string {
+	var buf strings.Builder
+	skip := 0          // depth inside tags whose content is dropped
+	open := []string{} // allowed tags left open
+
+	z := html.NewTokenizer(strings.NewReader(s))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			for i := len(open) - 1; i >= 0; i-- {
+				buf.WriteString("</" + open[i] + ">")
+			}
+			return buf.String()
+		}
+
+		tok := z.Token()
+
+		switch tt {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if Includes(htmlDropContent, tok.Data) {
+				if tt == html.StartTagToken {
+					skip++
+				}
+				continue
+			}
+			if skip > 0 {
+				continue
+			}
+			if attrs, ok := htmlAllowlist[tok.Data]; ok {
+				writeTag(&buf, tok, attrs)
+				switch {
+				case tok.Data == "br":
+				case tt == html.SelfClosingTagToken:
+					buf.WriteString("</" + tok.Data + ">")
+				default:
+					open = append(open, tok.Data)
+				}
+			}
+		case html.EndTagToken:
+			if Includes(htmlDropContent, tok.Data) {
+				if skip > 0 {
+					skip--
+				}
+				continue
+			}
+			if skip > 0 {
+				continue
+			}
+			// close the tags opened since the matching start tag, if any
+			idx := -1
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == tok.Data {
+					idx = i
+					break
+				}
+			}
+			if idx == -1 {
+				continue
+			}
+			for i := len(open) - 1; i >= idx; i-- {
+				buf.WriteString("</" + open[i] + ">")
+			}
+			open = open[:idx]
+		case html.TextToken:
+			if skip == 0 {
+				textEscaper.WriteString(&buf, tok.Data)
+			}
+		}
+	}
+}
+
+// writeTag writes the start tag with its allowed attributes
+func writeTag(buf *strings.Builder, tok html.Token, allowed []string) {
+	buf.WriteString("<" + tok.Data)
+	for _, attr := range tok.Attr {
+		if attr.Namespace != "" || !(Includes(allowed, attr.Key) || Includes(htmlGlobalAttrs, attr.Key)) {
+			continue
+		}
+		if attr.Key == "href" && !isSafeURL(attr.Val) {
+			continue
+		}
+		buf.WriteString(" " + attr.Key + `="`)
+		attrEscaper.WriteString(buf, attr.Val)
+		buf.WriteString(`"`)
+	}
+	if tok.Data == "br" {
+		buf.WriteString(" /")
+	}
+	buf.WriteString(">")
+}
+
+// isSafeURL reports whether the URL is relative or uses one of the safe schemes
+func isSafeURL(u string) bool {
+	u = strings.TrimSpace(u)
+
+	idx := strings.IndexAny(u, ":/?#")
+	if idx < 0 || u[idx] != ':' {
+		return true // no scheme, relative URL
+	}
+
+	return Includes(htmlSafeSchemes, strings.ToLower(u[:idx]))
+}
+
+// ParseHTML processes a translated HTML string with the given arguments.
+// The translation is sanitized against the allowlist and the arguments are always escaped:
+//   - Single struct or map: uses html/template parsing
+//   - Other arguments: are HTML escaped and formatted like Parse
+func ParseHTML(translated string, args ...any) string {
+
+	sanitized := SanitizeHTML(translated)
+
+	if len(args) == 1 {
+		v := reflect.ValueOf(args[0])
+		for v.Kind() == reflect.Ptr && !v.IsNil() {
+			v = v.Elem()
+		}
+
+		if (v.Kind() == reflect.Struct && !v.IsZero() && v.NumField() > 0) || (v.Kind() == reflect.Map && v.Len() > 0) {
+			tmpl, err := htmltemplate.New("i18n").Parse(sanitized)
+			if err != nil {
+				return sanitized // Fallback on parse failure
+			}
+
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, args[0]); err != nil {
+				return sanitized // Fallback on execution failure
+			}
+			return buf.String()
+		}
+	}
+
+	// Sanitize again, hrefs may come from the arguments
+	return SanitizeHTML(Parse(sanitized, escapeHTMLArgs(args)...))
+}
+
+// escapeHTMLArgs escapes the arguments substituted into an HTML translation.
+// Numbers, booleans and template.HTML values are kept as is, slices and arrays are escaped element-wise.
+func escapeHTMLArgs(args []any) []any {
+	escaped := make([]any, len(args))
+
+	for i, arg := range args {
+		if h, ok := arg.(htmltemplate.HTML); ok {
+			escaped[i] = h
+			continue
+		}
+
+		v := reflect.ValueOf(arg)
+		switch v.Kind() {
+		case reflect.Invalid,
+			reflect.Bool,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+			reflect.Float32, reflect.Float64:
+			escaped[i] = arg
+		case reflect.Array, reflect.Slice:
+			elems := make([]any, v.Len())
+			for j := 0; j < v.Len(); j++ {
+				elems[j] = v.Index(j).Interface()
+			}
+			escaped[i] = escapeHTMLArgs(elems)
+		default:
+			escaped[i] = htmltemplate.HTMLEscapeString(fmt.Sprint(arg))
+		}
+	}
+
+	return escaped
+}
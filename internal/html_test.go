@@ -0,0 +1,67 @@
+package internal
+
+import (
+	htmltemplate "html/template"
+	"testing"
+)
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"allowed tags", `Hello <b>world</b>, <a href="/login" title="Login">sign in</a><br>`, `Hello <b>world</b>, <a href="/login" title="Login">sign in</a><br />`},
+		{"script with content", `a<script>alert(1)</script>b`, `ab`},
+		{"style with content", `<style>body{display:none}</style>text`, `text`},
+		{"nested dropped tags", `<noscript><script>x</script>y</noscript>z`, `z`},
+		{"unknown tag keeps its text", `<div>text</div>`, `text`},
+		{"event handler attributes", `<b onclick="alert(1)" onmouseover=x>bold</b>`, `<b>bold</b>`},
+		{"style attribute", `<span style="color:red" class="x">t</span>`, `<span class="x">t</span>`},
+		{"javascript url", `<a href="javascript:alert(1)">x</a>`, `<a>x</a>`},
+		{"javascript url with case and spaces", `<a href="  JavaScript:alert(1)">x</a>`, `<a>x</a>`},
+		{"javascript url with entities", `<a href="jav&#x09;ascript:alert(1)">x</a>`, `<a>x</a>`},
+		{"data url", `<a href="data:text/html;base64,PHNjcmlwdD4=">x</a>`, `<a>x</a>`},
+		{"safe schemes", `<a href="mailto:a@b.c">m</a><a href="https://x.y/?a=1&amp;b=2">h</a>`, `<a href="mailto:a@b.c">m</a><a href="https://x.y/?a=1&amp;b=2">h</a>`},
+		{"unclosed tags", `<b>bold <i>italic`, `<b>bold <i>italic</i></b>`},
+		{"stray end tag", `text</b></strong>`, `text`},
+		{"misnested tags", `<b><i>x</b>y</i>`, `<b><i>x</i></b>y`},
+		{"self-closing tag", `<b/>x`, `<b></b>x`},
+		{"entities", `Tom &amp; Jerry &lt;script&gt; &copy;`, `Tom &amp; Jerry &lt;script&gt; ©`},
+		{"bare less-than", `a < b && c > d`, `a &lt; b &amp;&amp; c &gt; d`},
+		{"attribute escaping", `<span title="&quot;><script>">t</span>`, `<span title="&#34;&gt;&lt;script&gt;">t</span>`},
+		{"comment", `a<!-- <script>x</script> -->b`, `ab`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeHTML(tt.in); got != tt.want {
+				t.Errorf("SanitizeHTML(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseHTML(t *testing.T) {
+	tests := []struct {
+		name       string
+		translated string
+		args       []any
+		want       string
+	}{
+		{"escaped argument", `Hello <b>%s</b>`, []any{`<script>alert(1)</script>`}, `Hello <b>&lt;script&gt;alert(1)&lt;/script&gt;</b>`},
+		{"html argument", `Hello %s`, []any{htmltemplate.HTML(`<i>alice</i>`)}, `Hello <i>alice</i>`},
+		{"html argument is sanitized", `Hello %s`, []any{htmltemplate.HTML(`<a href="javascript:x" onclick="y">alice</a>`)}, `Hello <a>alice</a>`},
+		{"unsafe href from argument", `<a href="%s">link</a>`, []any{"javascript:alert(1)"}, `<a>link</a>`},
+		{"template argument", `Hello <b>{{.Name}}</b>`, []any{map[string]string{"Name": "<i>bob</i>"}}, `Hello <b>&lt;i&gt;bob&lt;/i&gt;</b>`},
+		{"number argument", `<b>%d</b> items`, []any{3}, `<b>3</b> items`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseHTML(tt.translated, tt.args...); got != tt.want {
+				t.Errorf("ParseHTML(%q) = %q, want %q", tt.translated, got, tt.want)
+			}
+		})
+	}
+}
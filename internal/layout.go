@@ -0,0 +1,152 @@
+package internal
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Layout describes where the locale files of a language and a bundle are located.
+//
+// A layout is a slash separated path pattern relative to the locales directory, with the placeholders
+// `{lang}` and `{bundle}`, and the glob wildcards `*`, `?` and `**` (any number of directories), e.g.
+//
+//	{lang}/{bundle}.*
+//	{bundle}/{lang}.yaml
+//	**/{bundle}/{lang}.json
+type Layout struct {
+	pattern string
+	re      *regexp.Regexp
+}
+
+// CompileLayout parses a layout pattern
+func CompileLayout(pattern string) (*Layout, error) {
+	if !strings.Contains(pattern, "{lang}") || !strings.Contains(pattern, "{bundle}") {
+		return nil, fmt.Errorf("layout %q must contain both {lang} and {bundle}", pattern)
+	}
+
+	re, err := compileGlob(pattern, map[string]string{
+		"{lang}":   `(?P<lang>[A-Za-z0-9_-]+)`,
+		"{bundle}": `(?P<bundle>[^/]+?)`,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Layout{pattern: pattern, re: re}, nil
+}
+
+// Match reports whether the file path matches the layout and returns its language and bundle name.
+// The layout is matched against the end of the path, so the path may contain the locales directory.
+func (l *Layout) Match(fpath string) (lang, bundle string, ok bool) {
+	m := l.re.FindStringSubmatch(path.Clean(fpath))
+	if m == nil {
+		return "", "", false
+	}
+
+	return m[l.re.SubexpIndex("lang")], m[l.re.SubexpIndex("bundle")], true
+}
+
+// Path returns the path of the locale file of the language and bundle, relative to the locales directory.
+// The `**` wildcards are dropped and the `*` wildcards of the extension are replaced by ext.
+func (l *Layout) Path(lang, bundle, ext string) string {
+	p := strings.ReplaceAll(l.pattern, "**/", "")
+	p = strings.ReplaceAll(p, "**", "")
+	p = strings.ReplaceAll(p, "{lang}", lang)
+	p = strings.ReplaceAll(p, "{bundle}", bundle)
+
+	if dot := strings.LastIndexByte(p, '.'); dot >= 0 && strings.ContainsAny(p[dot:], "*?") {
+		p = p[:dot+1] + strings.TrimPrefix(ext, ".")
+	}
+
+	return p
+}
+
+// String returns the layout pattern
+func (l *Layout) String() string {
+	return l.pattern
+}
+
+// Glob returns the names of all files matching the pattern, like fs.Glob,
+// but also supports the `**` wildcard which matches any number of directories.
+func Glob(fileSystem fs.FS, pattern string) ([]string, error) {
+	if !strings.Contains(pattern, "**") {
+		return fs.Glob(fileSystem, pattern)
+	}
+
+	re, err := compileGlob(pattern, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// walk from the static prefix of the pattern
+	root := "."
+	if idx := strings.IndexAny(pattern, "*?["); idx > 0 {
+		if dir := path.Dir(pattern[:idx+1]); dir != "" {
+			root = dir
+		}
+	}
+
+	matches := []string{}
+	err = fs.WalkDir(fileSystem, root, func(fpath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // skip unreadable directories
+		}
+		if !d.IsDir() && re.MatchString(fpath) {
+			matches = append(matches, fpath)
+		}
+		return nil
+	})
+
+	return matches, err
+}
+
+// compileGlob converts a glob pattern to a regular expression matching the end of a path,
+// the placeholders are replaced by their regular expression.
+func compileGlob(pattern string, placeholders map[string]string) (*regexp.Regexp, error) {
+	var buf strings.Builder
+	buf.WriteString(`(?:^|/)`)
+
+	for i := 0; i < len(pattern); {
+		rest := pattern[i:]
+
+		replaced := false
+		for placeholder, expr := range placeholders {
+			if strings.HasPrefix(rest, placeholder) {
+				buf.WriteString(expr)
+				i += len(placeholder)
+				replaced = true
+				break
+			}
+		}
+		if replaced {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(rest, "**/"):
+			buf.WriteString(`(?:[^/]+/)*`)
+			i += 3
+		case strings.HasPrefix(rest, "**"):
+			buf.WriteString(`.*`)
+			i += 2
+		case rest[0] == '*':
+			buf.WriteString(`[^/]*`)
+			i++
+		case rest[0] == '?':
+			buf.WriteString(`[^/]`)
+			i++
+		default:
+			_, size := utf8.DecodeRuneInString(rest)
+			buf.WriteString(regexp.QuoteMeta(rest[:size]))
+			i += size
+		}
+	}
+
+	buf.WriteString(`$`)
+
+	return regexp.Compile(buf.String())
+}
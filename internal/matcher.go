@@ -1,11 +1,23 @@
 package internal
 
-import "golang.org/x/text/language"
+import (
+	"sync"
+
+	"golang.org/x/text/language"
+)
 
 type Matcher struct {
+	mu      sync.RWMutex
 	strict  bool
+	pseudo  bool // whether the pseudo locales are served
 	langs   []language.Tag
 	matcher language.Matcher
+
+	setupOnce sync.Once
+	setup     func() []language.Tag // called once before first use, see Lazy
+
+	parent          *Matcher     // matcher sharing its languages, see Derive
+	defaultLanguage language.Tag // default language of a derived matcher, the one of the parent when undefined
 }
 
 func NewMatcher(defaultLanguage language.Tag, limits ...language.Tag) *Matcher {
@@ -17,24 +29,120 @@ func NewMatcher(defaultLanguage language.Tag, limits ...language.Tag) *Matcher {
 	}
 }
 
+// Derive returns a matcher sharing the languages of m, with its own default language:
+// the languages added to either matcher are known to both, but SetDefaultLanguage
+// only changes the default language of the derived matcher.
+func (m *Matcher) Derive() *Matcher {
+	return &Matcher{parent: m}
+}
+
 // Match returns the best match for any of the given tags, along with
 // a unique index associated with the returned tag and a confidence
 // score.
+// When pseudo locales are enabled and none of the tags preceding a pseudo locale matches, the pseudo locale is returned as is.
 func (m *Matcher) Match(t ...language.Tag) language.Tag {
+	if tag, ok := m.match(t...); ok {
+		return tag
+	}
+	return m.DefaultLanguage()
+}
+
+// match implements Match, ok is false when none of the tags matches
+func (m *Matcher) match(t ...language.Tag) (language.Tag, bool) {
+	if m.parent != nil {
+		return m.parent.match(t...)
+	}
+
+	m.Prepare()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.pseudo {
+		for p, tag := range t {
+			if !IsPseudo(tag) {
+				continue
+			}
+			// the tags preferred to the pseudo locale
+			if p > 0 {
+				if _, i, conf := m.matcher.Match(t[:p]...); conf > language.Low {
+					return m.langs[i], true
+				}
+			}
+			return tag, true
+		}
+	}
+
 	_, i, conf := m.matcher.Match(t...)
 	if conf <= language.Low {
-		return m.langs[0]
+		return m.langs[0], false
 	}
 
-	return m.langs[i]
+	return m.langs[i], true
+}
+
+// Negotiate returns the supported language matching the first of the given tags that has a match,
+// along with the index of that tag, ok is false when none of them matches.
+// Unlike Match, each tag is matched on its own, so the order of the tags is the order of preference.
+func (m *Matcher) Negotiate(t ...language.Tag) (tag language.Tag, index int, ok bool) {
+	if m.parent != nil {
+		if tag, index, ok = m.parent.Negotiate(t...); ok {
+			return tag, index, ok
+		}
+		return m.DefaultLanguage(), -1, false
+	}
+
+	m.Prepare()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for i, want := range t {
+		if want == language.Und {
+			continue
+		}
+
+		if m.pseudo && IsPseudo(want) {
+			return want, i, true
+		}
+
+		if _, j, conf := m.matcher.Match(want); conf > language.Low {
+			return m.langs[j], i, true
+		}
+	}
+
+	return m.langs[0], -1, false
 }
 
 // MatchOrAdd acts like Match but it checks and adds a language tag, if not found,
 // when the `Matcher.strict` field is true (when no tags are provided by the caller)
 // and they should be dynamically added to the list.
 func (m *Matcher) MatchOrAdd(t language.Tag) language.Tag {
+	if m.parent != nil {
+		return m.parent.MatchOrAdd(t)
+	}
+
+	m.Prepare()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.matchOrAdd(t)
+}
+
+// matchOrAdd implements MatchOrAdd, the write lock must be held.
+// The pseudo locales are kept as they are, so they are not folded into their base language, and the other way round.
+func (m *Matcher) matchOrAdd(t language.Tag) language.Tag {
+	if IsPseudo(t) {
+		if !m.strict && !Includes(m.langs, t) {
+			m.langs = append(m.langs, t)
+			m.matcher = language.NewMatcher(m.langs)
+		}
+		return t
+	}
+
 	_, i, conf := m.matcher.Match(t)
-	if conf <= language.Low {
+	if conf <= language.Low || IsPseudo(m.langs[i]) {
 		if !m.strict {
 			// not found, add it now.
 			m.langs = append(m.langs, t)
@@ -46,15 +154,190 @@ func (m *Matcher) MatchOrAdd(t language.Tag) language.Tag {
 	return m.langs[i]
 }
 
+// Add adds the languages, like MatchOrAdd, without running the setup function registered by Lazy
+func (m *Matcher) Add(t ...language.Tag) {
+	if m.parent != nil {
+		m.parent.Add(t...)
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, tag := range t {
+		m.matchOrAdd(tag)
+	}
+}
+
+// Insert adds the languages as they are, even when they match a known language, e.g. en-GB when en is known,
+// unless the languages are limited
+func (m *Matcher) Insert(t ...language.Tag) {
+	if m.parent != nil {
+		m.parent.Insert(t...)
+		return
+	}
+
+	m.Prepare()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.strict {
+		return
+	}
+
+	added := false
+	for _, tag := range t {
+		if !Includes(m.langs, tag) {
+			m.langs = append(m.langs, tag)
+			added = true
+		}
+	}
+	if added {
+		m.matcher = language.NewMatcher(m.langs)
+	}
+}
+
 func (m *Matcher) DefaultLanguage() language.Tag {
+	if m.parent != nil {
+		m.mu.RLock()
+		t := m.defaultLanguage
+		m.mu.RUnlock()
+
+		if t != language.Und {
+			return t
+		}
+		return m.parent.DefaultLanguage()
+	}
+
+	m.Prepare()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
 	return m.langs[0]
 }
 
+// Languages returns a copy of the languages known to the matcher,
+// the default language first.
 func (m *Matcher) Languages() []language.Tag {
-	return m.langs
+	if m.parent != nil {
+		langs := m.parent.Languages()
+
+		m.mu.RLock()
+		t := m.defaultLanguage
+		m.mu.RUnlock()
+
+		if t == language.Und {
+			return langs
+		}
+		if idx := IndexOf(langs, t); idx != -1 {
+			langs = append(langs[:idx], langs[idx+1:]...)
+		}
+		return append([]language.Tag{t}, langs...)
+	}
+
+	m.Prepare()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]language.Tag(nil), m.langs...)
 }
 
 func (m *Matcher) SetLanguages(langs []language.Tag) {
+	if m.parent != nil {
+		m.parent.SetLanguages(langs)
+		return
+	}
+
+	m.Prepare()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.langs = langs
+	m.matcher = language.NewMatcher(langs)
+}
+
+// SetDefaultLanguage moves t to the front of the language list,
+// adding it when it is not known yet.
+// The default language of a derived matcher is changed without changing its parent.
+func (m *Matcher) SetDefaultLanguage(t language.Tag) {
+	if m.parent != nil {
+		m.mu.Lock()
+		m.defaultLanguage = t
+		m.mu.Unlock()
+		return
+	}
+
+	m.Prepare()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	idx := IndexOf(m.langs, t)
+
+	if idx == 0 {
+		return
+	}
+
+	langs := append([]language.Tag(nil), m.langs...)
+	if idx == -1 {
+		langs = append([]language.Tag{t}, langs...)
+	} else {
+		langs[0], langs[idx] = t, langs[0]
+	}
+
 	m.langs = langs
 	m.matcher = language.NewMatcher(langs)
 }
+
+// SetPseudo enables or disables serving the pseudo locales
+func (m *Matcher) SetPseudo(enabled bool) {
+	if m.parent != nil {
+		m.parent.SetPseudo(enabled)
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.pseudo = enabled
+}
+
+// Lazy defers the discovery of the languages until the matcher is first used:
+// setup is called once before then, and the languages it returns are added to the matcher.
+// It must be called before the matcher is used, and setup must not use the matcher.
+func (m *Matcher) Lazy(setup func() []language.Tag) {
+	if m.parent != nil {
+		m.parent.Lazy(setup)
+		return
+	}
+
+	m.setup = setup
+}
+
+// Prepare runs the setup function registered by Lazy, if any.
+// It is called by the other methods of the matcher.
+func (m *Matcher) Prepare() {
+	if m.parent != nil {
+		m.parent.Prepare()
+		return
+	}
+
+	if m.setup == nil {
+		return
+	}
+
+	m.setupOnce.Do(func() {
+		langs := m.setup()
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		for _, t := range langs {
+			m.matchOrAdd(t)
+		}
+	})
+}
@@ -0,0 +1,71 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/language"
+)
+
+var (
+	aliasMu sync.RWMutex
+	aliases = map[language.Tag]language.Tag{}
+)
+
+// SetLanguageAlias maps the alias language to the target language, e.g. `zh-SG -> zh-Hans`.
+// Both are normalised first, so `zh_sg` and `zh-SG` are the same alias.
+func SetLanguageAlias(alias, target string) error {
+	from, err := parseCanonical(alias)
+	if err != nil {
+		return fmt.Errorf("invalid language alias %q: %w", alias, err)
+	}
+
+	to, err := parseCanonical(target)
+	if err != nil {
+		return fmt.Errorf("invalid language alias target %q: %w", target, err)
+	}
+
+	aliasMu.Lock()
+	if from == to {
+		delete(aliases, from)
+	} else {
+		aliases[from] = to
+	}
+	aliasMu.Unlock()
+
+	// the cached tags may be outdated now
+	clearLanguageTagCache()
+
+	return nil
+}
+
+// NormalizeLanguage normalises a language string into a language tag:
+//   - surrounding spaces are trimmed and `_` separators are replaced by `-`, e.g. `pt_br` -> `pt-BR`
+//   - deprecated and legacy codes are replaced, e.g. `iw` -> `he`, `in` -> `id`
+//   - the configured aliases are applied, e.g. `zh-SG` -> `zh-Hans`
+func NormalizeLanguage(lang string) (language.Tag, error) {
+	tag, err := parseCanonical(lang)
+	if err != nil {
+		return language.Und, err
+	}
+
+	aliasMu.RLock()
+	defer aliasMu.RUnlock()
+
+	if target, ok := aliases[tag]; ok {
+		return target, nil
+	}
+
+	return tag, nil
+}
+
+// parseCanonical parses the language string with BCP 47 canonicalization
+func parseCanonical(lang string) (language.Tag, error) {
+	lang = strings.ReplaceAll(strings.TrimSpace(lang), "_", "-")
+	if lang == "" {
+		return language.Und, fmt.Errorf("empty language")
+	}
+
+	return (language.Default | language.SuppressScript).Parse(lang)
+}
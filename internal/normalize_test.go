@@ -0,0 +1,90 @@
+package internal
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNormalizeLanguage(t *testing.T) {
+	tests := []struct {
+		lang    string
+		want    string
+		wantErr bool
+	}{
+		{"zh_CN", "zh-CN", false},
+		{"pt_br", "pt-BR", false},
+		{"EN_us", "en-US", false},
+		{" en ", "en", false},
+		// 废弃的语言代码
+		{"iw", "he", false},
+		{"in", "id", false},
+		// 保留文字和地区
+		{"zh-Hant", "zh-Hant", false},
+		{"zh-TW", "zh-TW", false},
+		{"zh-Hans-CN", "zh-Hans-CN", false},
+		{"", "", true},
+		{"not a language!", "", true},
+	}
+
+	for _, tt := range tests {
+		tag, err := NormalizeLanguage(tt.lang)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NormalizeLanguage(%q) error = %v, want error %v", tt.lang, err, tt.wantErr)
+			continue
+		}
+		if err == nil && tag.String() != tt.want {
+			t.Errorf("NormalizeLanguage(%q) = %s, want %s", tt.lang, tag, tt.want)
+		}
+	}
+}
+
+func TestSetLanguageAlias(t *testing.T) {
+	t.Cleanup(func() { SetLanguageAlias("zh-SG", "zh-SG") })
+
+	// 缓存的结果在设置别名后失效
+	if got := ParseLanguageTag("zh_sg"); got.String() != "zh-SG" {
+		t.Fatalf("ParseLanguageTag(zh_sg) = %s, want zh-SG", got)
+	}
+
+	if err := SetLanguageAlias("zh_sg", "zh-Hans"); err != nil {
+		t.Fatal(err)
+	}
+	for _, lang := range []string{"zh-SG", "zh_sg", "ZH_SG"} {
+		if got := ParseLanguageTag(lang); got.String() != "zh-Hans" {
+			t.Errorf("ParseLanguageTag(%q) = %s, want zh-Hans", lang, got)
+		}
+	}
+
+	// 别名与目标相同时删除别名
+	if err := SetLanguageAlias("zh-SG", "zh_SG"); err != nil {
+		t.Fatal(err)
+	}
+	if got := ParseLanguageTag("zh_sg"); got.String() != "zh-SG" {
+		t.Errorf("ParseLanguageTag(zh_sg) after removal = %s, want zh-SG", got)
+	}
+
+	if err := SetLanguageAlias("invalid!", "en"); err == nil {
+		t.Error("invalid alias: err = nil, want an error")
+	}
+	if err := SetLanguageAlias("en-XX", "invalid!"); err == nil {
+		t.Error("invalid target: err = nil, want an error")
+	}
+}
+
+func TestParseLanguageTagCache(t *testing.T) {
+	clearLanguageTagCache()
+	t.Cleanup(clearLanguageTagCache)
+
+	// 缓存的数量有上限
+	for i := 0; i < languageTagCacheLimit*2; i++ {
+		ParseLanguageTag(fmt.Sprintf("invalid-%d!", i))
+	}
+	if size := languageTagCacheSize.Load(); size > languageTagCacheLimit {
+		t.Errorf("cache size = %d, want at most %d", size, languageTagCacheLimit)
+	}
+
+	// 缓存已满时仍然正确解析
+	if got := ParseLanguageTag("pt_br"); got.String() != "pt-BR" {
+		t.Errorf("ParseLanguageTag(pt_br) = %s, want pt-BR", got)
+	}
+}
@@ -0,0 +1,114 @@
+package internal
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/language"
+)
+
+// Pseudo locales, as used by Android and Chromium
+var (
+	PseudoAccents = language.MustParse("en-XA") // accented and expanded text
+	PseudoBidi    = language.MustParse("ar-XB") // right-to-left text
+)
+
+// pseudoAccents maps ASCII letters to accented look-alikes
+var pseudoAccents = map[rune]rune{
+	'a': 'á', 'b': 'ƀ', 'c': 'ç', 'd': 'ð', 'e': 'é', 'f': 'ƒ', 'g': 'ĝ', 'h': 'ĥ', 'i': 'î', 'j': 'ĵ',
+	'k': 'ķ', 'l': 'ļ', 'm': 'ɱ', 'n': 'ñ', 'o': 'ö', 'p': 'þ', 'q': 'ǫ', 'r': 'ŕ', 's': 'š', 't': 'ţ',
+	'u': 'û', 'v': 'ṽ', 'w': 'ŵ', 'x': 'ẋ', 'y': 'ý', 'z': 'ž',
+	'A': 'Å', 'B': 'Ɓ', 'C': 'Ç', 'D': 'Ð', 'E': 'É', 'F': 'Ƒ', 'G': 'Ĝ', 'H': 'Ĥ', 'I': 'Î', 'J': 'Ĵ',
+	'K': 'Ķ', 'L': 'Ļ', 'M': 'Ṁ', 'N': 'Ñ', 'O': 'Ö', 'P': 'Þ', 'Q': 'Ǫ', 'R': 'Ŕ', 'S': 'Š', 'T': 'Ţ',
+	'U': 'Û', 'V': 'Ṽ', 'W': 'Ŵ', 'X': 'Ẋ', 'Y': 'Ý', 'Z': 'Ž',
+}
+
+// pseudoProtected matches the parts of a text which must stay untouched:
+// printf verbs, template actions and HTML tags
+var pseudoProtected = regexp.MustCompile(`%(\[\d+\])?[-+# 0]*(\d+|\*)?(\.(\d+|\*)?)?[a-zA-Z%]|\{\{.*?\}\}|<[^<>]+>`)
+
+const (
+	rlo = "\u202e" // right-to-left override
+	pdf = "\u202c" // pop directional formatting
+	rlm = "\u200f" // right-to-left mark
+)
+
+// IsPseudo reports whether the tag is one of the pseudo locales
+func IsPseudo(tag language.Tag) bool {
+	return tag == PseudoAccents || tag == PseudoBidi
+}
+
+// Pseudo returns the pseudo localized text for the pseudo locale tag.
+// For en-XA letters are accented and the text is expanded by about 30%,
+// for ar-XB the text is rendered right-to-left. In both cases the text is wrapped in brackets,
+// printf verbs, template actions and HTML tags are kept as is.
+// Other tags return the text unchanged.
+func Pseudo(tag language.Tag, txt string) string {
+	var transform func(s string) string
+
+	switch tag {
+	case PseudoAccents:
+		transform = pseudoAccent
+	case PseudoBidi:
+		transform = pseudoBidi
+	default:
+		return txt
+	}
+
+	var buf strings.Builder
+	letters := 0
+	last := 0
+
+	buf.WriteString("[")
+	for _, loc := range pseudoProtected.FindAllStringIndex(txt, -1) {
+		letters += utf8.RuneCountInString(txt[last:loc[0]])
+		buf.WriteString(transform(txt[last:loc[0]]))
+		buf.WriteString(txt[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	letters += utf8.RuneCountInString(txt[last:])
+	buf.WriteString(transform(txt[last:]))
+
+	if tag == PseudoAccents {
+		// expand the text to reveal truncation
+		buf.WriteString(" ")
+		buf.WriteString(strings.Repeat("~", letters*3/10+1))
+	}
+	buf.WriteString("]")
+
+	return buf.String()
+}
+
+func pseudoAccent(s string) string {
+	return strings.Map(func(r rune) rune {
+		if a, ok := pseudoAccents[r]; ok {
+			return a
+		}
+		return r
+	}, s)
+}
+
+// pseudoBidi wraps every word with a right-to-left override
+func pseudoBidi(s string) string {
+	var buf strings.Builder
+	word := false
+
+	for _, r := range s {
+		isSpace := r == ' ' || r == '\t' || r == '\n'
+		if !isSpace && !word {
+			buf.WriteString(rlm + rlo)
+			word = true
+		} else if isSpace && word {
+			buf.WriteString(pdf + rlm)
+			word = false
+		}
+		buf.WriteRune(r)
+	}
+
+	if word {
+		buf.WriteString(pdf + rlm)
+	}
+
+	return buf.String()
+}
@@ -2,8 +2,10 @@ package internal
 
 import (
 	"context"
+	"html/template"
 
 	"github.com/epkgs/i18n/types"
+	"golang.org/x/text/language"
 )
 
 // i18nString represents an internationalizable string structure containing the context and parameters needed for translation
@@ -26,9 +28,13 @@ func NewString(b *i18nBundle, txt string, args ...any) types.Stringer {
 }
 
 // i18nString implements the fmt.Stringer interface, returning a localized string after parameter replacement
-// This method processes the s.txt template string with s.args parameters to generate the final string
+// This method processes the s.txt template string with s.args parameters to generate the final string,
+// or the text of the default language for the keys declared by Define
 // Returns the processed string
 func (s *i18nString) String() string {
+	if s.b.isDefined(s.txt) {
+		return s.b.transLangs(nil, s.txt, s.args...)
+	}
 	return Parse(s.txt, s.args...)
 }
 
@@ -42,3 +48,48 @@ func (s *i18nString) T(ctx context.Context) string {
 func (s *i18nString) TL(langs ...string) string {
 	return s.b.transLangs(langs, s.txt, s.args...)
 }
+
+// Language returns the language T translates the string in, based on language preferences in the context
+func (s *i18nString) Language(ctx context.Context) language.Tag {
+	return s.b.matchLangs(GetAcceptLanguages(ctx))
+}
+
+// i18nHTMLString represents an internationalizable HTML string, its translations are sanitized
+// and its arguments escaped
+type i18nHTMLString struct {
+	b    *i18nBundle // Pointer to the internationalization bundle instance for handling translations in different languages
+	txt  string      // Raw HTML content
+	args []any       // List of arguments passed to the text for formatting
+}
+
+// NewHTMLString creates and returns a new i18nHTMLString instance
+//   - b:   Bundle instance used for internationalization
+//   - txt: HTML text to be translated
+//   - args: Arguments used to replace placeholders in the text
+func NewHTMLString(b *i18nBundle, txt string, args ...any) types.HTMLStringer {
+	return &i18nHTMLString{
+		b:    b,
+		txt:  txt,
+		args: args,
+	}
+}
+
+// String returns the sanitized HTML of the untranslated text
+func (s *i18nHTMLString) String() string {
+	return ParseHTML(s.txt, s.args...)
+}
+
+// T returns the translated HTML based on language preferences in the context
+func (s *i18nHTMLString) T(ctx context.Context) template.HTML {
+	return s.TL(GetAcceptLanguages(ctx)...)
+}
+
+// Language returns the language T translates the HTML in, based on language preferences in the context
+func (s *i18nHTMLString) Language(ctx context.Context) language.Tag {
+	return s.b.matchLangs(GetAcceptLanguages(ctx))
+}
+
+// TL returns the translated HTML based on the specified language preferences
+func (s *i18nHTMLString) TL(langs ...string) template.HTML {
+	return template.HTML(s.b.transHTMLLangs(langs, s.txt, s.args...))
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"text/template"
 
 	"github.com/epkgs/i18n/errors"
@@ -11,15 +13,15 @@ import (
 	"gopkg.in/ini.v1"
 )
 
-// templateParser is a template parser for internationalization
-var templateParser = template.New("i18n")
+// templateCache caches the parsed templates of the messages, nil when a message fails to parse
+var templateCache sync.Map
 
 // parseTemplate parses a template message with the given argument
 func parseTemplate(msg string, arg1 any) string {
 
 	// Parse struct or map using text/template
-	tmpl, err := templateParser.Parse(msg)
-	if err != nil {
+	tmpl := compileTemplate(msg)
+	if tmpl == nil {
 		return msg // Fallback on parse failure
 	}
 
@@ -30,21 +32,56 @@ func parseTemplate(msg string, arg1 any) string {
 	return buf.String()
 }
 
-// languageTagCache caches parsed language tags
-var languageTagCache = make(map[string]language.Tag)
+// compileTemplate returns the parsed template of the message, parsing it on first use
+func compileTemplate(msg string) *template.Template {
+	if tmpl, ok := templateCache.Load(msg); ok {
+		return tmpl.(*template.Template)
+	}
+
+	tmpl, err := template.New("i18n").Parse(msg)
+	if err != nil {
+		tmpl = nil
+	}
+	templateCache.Store(msg, tmpl)
 
-// parseLanguageTag parses a language string into a language.Tag and caches the result
+	return tmpl
+}
+
+// languageTagCacheLimit is the maximum number of language strings cached by ParseLanguageTag,
+// as they come from the requests
+const languageTagCacheLimit = 1024
+
+var (
+	languageTagCache     sync.Map     // caches the normalised language tags, by language string
+	languageTagCacheSize atomic.Int64 // number of entries of languageTagCache
+)
+
+// ParseLanguageTag normalises a language string into a language.Tag and caches the result,
+// invalid languages are parsed as language.Und.
+// Once the cache is full, the other language strings are normalised on each call.
 func ParseLanguageTag(lang string) language.Tag {
-	if _, exist := languageTagCache[lang]; !exist {
-		t, e := language.Parse(lang)
-		if e != nil {
-			languageTagCache[lang] = language.Und
-		} else {
-			languageTagCache[lang] = t
+	if t, ok := languageTagCache.Load(lang); ok {
+		return t.(language.Tag)
+	}
+
+	t, err := NormalizeLanguage(lang)
+	if err != nil {
+		t = language.Und
+	}
+
+	if languageTagCacheSize.Load() < languageTagCacheLimit {
+		if _, loaded := languageTagCache.LoadOrStore(lang, t); !loaded {
+			languageTagCacheSize.Add(1)
 		}
 	}
 
-	return languageTagCache[lang]
+	return t
+}
+
+// clearLanguageTagCache drops the cached language tags
+func clearLanguageTagCache() {
+	languageTagCache.Clear()
+	languageTagCacheSize.Store(0)
 }
 
 func ParseLanguageTags(langs ...string) []language.Tag {
@@ -3,6 +3,7 @@ package types
 import (
 	"context"
 	"fmt"
+	"html/template"
 
 	"golang.org/x/text/language"
 )
@@ -19,7 +20,74 @@ type Bundler interface {
 	// args: Arguments passed to the error message
 	Err(text string, args ...any) Error
 
-	// SetDefaultLanguage Sets the default language
+	// HTML Returns a translatable string instance whose translation is HTML markup.
+	// The translation is sanitised against an allowlist of tags and the arguments are always escaped.
+	// text: The HTML text to translate.
+	// args: Arguments passed to the formatted string.
+	HTML(text string, args ...any) HTMLStringer
+
+	// Define Declares a message with its built-in translations and returns it.
+	// The built-in translations have the lowest priority, sources and locale files override them.
+	// key: The message key.
+	// text: The built-in translations by language code, e.g. Text{"en": "Not found", "zh-CN": "未找到"}.
+	Define(key string, text Text) Stringer
+
+	// NStr Returns a translatable string instance in singular or plural form based on quantity.
+	// n: Quantity, the singular form is used when a number equals 1 or a bool is true.
+	// one: The singular form text.
+	// others: The plural form text.
+	// args: Arguments passed to the formatted string.
+	NStr(n any, one, others string, args ...any) Stringer
+
+	// NErr Returns a translatable error instance in singular or plural form based on quantity.
+	// n: Quantity, the singular form is used when a number equals 1 or a bool is true.
+	// one: The singular form error message.
+	// others: The plural form error message.
+	// args: Arguments passed to the error message.
+	NErr(n any, one, others string, args ...any) Error
+
+	// Languages Returns the languages which have translations loaded, the default language first
+	Languages() []language.Tag
+
+	// Keys Returns the sorted translation keys of the language, without those of the parents
+	// lang: Language tag, one of Languages()
+	Keys(lang language.Tag) []string
+
+	// Lookup Returns the translation of the key in the language, without any fallback
+	// lang: Language tag, one of Languages()
+	// key: Translation key, the original text
+	// Returns the translation and whether it exists
+	Lookup(lang language.Tag, key string) (string, bool)
+
+	// Coverage Returns the ratio of the keys of the default language which are translated in the language,
+	// by the bundle or one of its parents
+	// lang: Language tag, one of Languages()
+	// Returns a value between 0 and 1, 1 when the default language has no keys
+	Coverage(lang language.Tag) float64
+
+	// Set Adds or overrides the translation of a key at runtime.
+	// The change is kept across Reload until ClearOverlay is called.
+	// lang: Language tag
+	// key: Translation key, the original text
+	// value: Translated text
+	Set(lang language.Tag, key, value string)
+
+	// Merge Adds or overrides the translations of several keys at runtime, atomically.
+	// The changes are kept across Reload until ClearOverlay is called.
+	// lang: Language tag
+	// keyValues: Translation keys and their translated texts
+	Merge(lang language.Tag, keyValues map[string]string)
+
+	// Delete Removes the translations of keys at runtime.
+	// The removal is kept across Reload until ClearOverlay is called.
+	// lang: Language tag
+	// keys: Translation keys
+	Delete(lang language.Tag, keys ...string)
+
+	// ClearOverlay Drops the changes made by Set, Merge and Delete
+	ClearOverlay()
+
+	// SetDefaultLanguage Sets the default language of the bundle, the other bundles keep theirs
 	// lang: Language tag to set as default
 	// Returns whether the setting was successful
 	SetDefaultLanguage(lang language.Tag) bool
@@ -28,6 +96,9 @@ type Bundler interface {
 	Reload()
 }
 
+// Text is a set of translations of a message by language code
+type Text map[string]string
+
 // Translator is an interface that provides translation capability
 // Implementations of this interface can translate content based on context language preferences
 type Translator interface {
@@ -44,3 +115,15 @@ type Stringer interface {
 	fmt.Stringer
 	Translator
 }
+
+// HTMLStringer is a translatable string whose translation is sanitised HTML markup,
+// ready to be used in html/template pipelines
+type HTMLStringer interface {
+	fmt.Stringer
+
+	// T returns the translated HTML based on the language preferences in the context
+	T(ctx context.Context) template.HTML
+
+	// TL returns the translated HTML based on the specified language preferences
+	TL(langs ...string) template.HTML
+}